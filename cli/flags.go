@@ -1,53 +1,433 @@
 package cli
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"strings"
+	"time"
+	"trivy-parser/i18n"
+	"trivy-parser/io"
+	"trivy-parser/processor"
+	"trivy-parser/render"
 )
 
+// notifyWebhookEnv는 -notify-webhook 기본값을 읽는 환경 변수입니다 (명령줄에 토큰이 노출되지 않도록).
+const notifyWebhookEnv = "TRIVY_PARSER_WEBHOOK_URL"
+
+// notifyBackoff는 웹후크 전송 첫 재시도 전 대기 시간입니다.
+const notifyBackoff = 2 * time.Second
+
 // Config는 CLI 플래그로부터 파싱된 설정을 담습니다.
 type Config struct {
 	InputFile   string
+	InputFiles  []string
 	OutputFile  string
 	Preprocess  bool
 	Pretty      bool
 	ExportExcel bool
+	Lang        i18n.Lang
+	SortKeys    []processor.SortKey
+
+	// 정책 카테고리 분류 규칙 (preprocess 파일명 prefix, Excel 시트)
+	Classifier *processor.Classifier
+
+	// 컴플라이언스 프레임워크 매핑 (nil이면 사용 안 함)
+	Compliance *processor.ComplianceMapping
+
+	// 로컬 Rego 정책 메타데이터 (nil이면 사용 안 함)
+	PolicyMetadata processor.PolicyMetadataIndex
+
+	// Preprocess 파일명 옵션
+	FilenameScheme processor.FilenameScheme
+	OnCollision    processor.CollisionPolicy
+	WriteIndex     bool
+	Normalize      bool
+	Projection     *processor.Projection
+	GroupBy        processor.GroupBy
+
+	// 압축 파일 출력 형식 (BundleNone이면 출력 디렉토리에 저장)
+	Bundle io.BundleFormat
+
+	// CODEOWNERS 담당자 규칙 (nil이면 사용 안 함)과 담당자별 파일 분리 여부
+	CodeOwners   *processor.CodeOwners
+	SplitByOwner bool
+
+	// 로컬 .tf 리소스 인덱스 (nil이면 사용 안 함)와 태그 필터/가중치/컬럼 옵션
+	Terraform        *processor.TerraformIndex
+	TerraformOptions processor.TerraformOptions
+
+	// 위험 점수 모델 (nil이면 사용 안 함)과 경과일 계산용 이전 스캔 목록 및 이력 데이터베이스
+	Risk          *processor.RiskModel
+	BaselineFiles []string
+	HistoryDB     string
+
+	// 조치 기한 정책 (nil이면 사용 안 함), 초과 판단 기준일, CI 게이트 심각도 (nil이면 게이트 없음, 빈 목록이면 전체)
+	SLA         *processor.SLAPolicy
+	SLAAsOf     time.Time
+	OverdueGate []string
+
+	// git blame 정보 제공 (nil이면 사용 안 함)
+	Blamer processor.Blamer
+
+	// 로컬 소스 스니펫 옵션 (SourceRoot가 비어 있으면 사용 안 함)
+	SourceRoot    string
+	SourceOptions processor.SourceOptions
+
+	// 청크 옵션 (LLM 리뷰용)
+	ChunkBudget int
+	Tokenizer   processor.Tokenizer
+
+	// 텍스트 렌더링 옵션 (AI 조치용 프롬프트)
+	Renderer *render.Renderer
+
+	// Excel 옵션
+	ExcelDetails bool
+
+	// 채팅 웹후크 알림 (nil이면 사용 안 함)과 요약/전송 옵션 (NotifyDryRun이면 전송하지 않고 페이로드 출력)
+	Notifier      *render.Notifier
+	NotifyWebhook string
+	NotifyOptions processor.NotifyOptions
+	NotifyRetry   io.RetryPolicy
+	NotifyDryRun  bool
 }
 
 // ParseFlags는 커맨드 라인 플래그를 파싱하고 검증합니다.
 func ParseFlags() *Config {
-	config := &Config{}
-
-	flag.StringVar(&config.InputFile, "input", "", "Input JSON file path (required)")
-	flag.StringVar(&config.OutputFile, "output", "", "Output file or directory path (required)")
-	flag.BoolVar(&config.Preprocess, "preprocess", false, "Preprocess: group by policy and split by target (.tf files)")
-	flag.BoolVar(&config.Pretty, "pretty", false, "Format JSON with indentation")
-	flag.BoolVar(&config.ExportExcel, "excel", false, "Export to Excel file (.xlsx) with Custom/Built-in sheets")
-
-	flag.Parse()
+	config, err := parseConfigInternal(flag.CommandLine, os.Args[1:], true)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("cli.error", err))
+		os.Exit(1)
+	}
 
 	// 필수 인자 검증
-	if config.InputFile == "" || config.OutputFile == "" {
+	if config.InputFile == "" || config.OutputFile == "" || len(config.InputFiles) == 0 {
 		printUsage()
 		os.Exit(1)
 	}
-
 	return config
 }
 
+// parseConfigInternal은 fs에 변환 옵션 플래그를 등록하고 args를 파싱하여 검증합니다.
+// setDefaultLang이 true이면 -lang 값을 기본 출력 언어로 설정합니다 (이후 메시지에 적용).
+// 입력/출력 경로는 검증하지 않습니다.
+func parseConfigInternal(fs *flag.FlagSet, args []string, setDefaultLang bool) (*Config, error) {
+	config := &Config{}
+
+	fs.StringVar(&config.InputFile, "input", "", "Input JSON file path (required). Excel mode accepts a comma-separated list of scans to build a Trend sheet")
+	fs.StringVar(&config.OutputFile, "output", "", "Output file or directory path (required)")
+	fs.BoolVar(&config.Preprocess, "preprocess", false, "Preprocess: group by policy and split by target (.tf files)")
+	fs.BoolVar(&config.WriteIndex, "index", false, "Preprocess: write index.json manifest describing all generated files")
+	bundle := fs.String("bundle", "", "Preprocess: write all output files into a single archive at -output instead of a directory (zip, tar.gz); always includes index.json")
+	fs.BoolVar(&config.Normalize, "normalize", false, "Preprocess: move policy metadata into a shared policies.json catalog and reference policy IDs only")
+	fs.BoolVar(&config.Pretty, "pretty", false, "Format JSON with indentation")
+	fs.BoolVar(&config.ExportExcel, "excel", false, "Export to Excel file (.xlsx) with one sheet per policy category (default: Custom/Built-in)")
+	fs.BoolVar(&config.ExcelDetails, "excel-details", false, "Excel: add Message, Description and Code snippet columns")
+
+	categoriesFile := fs.String("categories", "", "Policy category rules file (JSON) mapping namespace/ID patterns to named categories; each category becomes a preprocess filename prefix and an Excel sheet (default: builtin/custom)")
+	complianceSpec := fs.String("compliance", "", "Compliance mappings to enrich output with framework control references: 'builtin' (CIS AWS 1.4, PCI DSS 3.2.1, ISMS-P) and/or mapping file paths, comma-separated")
+	policyDir := fs.String("policy-dir", "", "Local Rego policy directory; merges METADATA annotations (owner, remediation examples, related resources, risk rationale) into matching policies by namespace")
+	codeOwnersFile := fs.String("codeowners", "", "CODEOWNERS file (GitHub/GitLab syntax) used to attach owning teams to findings by target path; adds a Code Owners column and Unowned sheet to Excel and writes unowned.json in preprocess mode")
+	splitBy := fs.String("split-by", "target", "Preprocess: output file unit (target, owner: one file per CODEOWNERS owner, requires -codeowners)")
+	terraformRoot := fs.String("terraform-root", "", "Local Terraform root; parses .tf files to attach resource file, module directory and tags to each finding")
+	tagFilter := fs.String("tag-filter", "", "Keep only findings whose resource tags match all conditions, e.g. \"Environment=prod,Owner=team-*\" (requires -terraform-root)")
+	tagWeights := fs.String("tag-weights", "", "Risk weight multipliers by resource tag, e.g. \"Environment=prod:2,DataClassification=confidential:1.5\" (requires -terraform-root)")
+	tagKeys := fs.String("tag-keys", strings.Join(processor.DefaultTagKeys, ","), "Excel: resource tag keys shown as columns and summarized in the Tags sheet (with -terraform-root)")
+	riskEnabled := fs.Bool("risk", false, "Score each finding by severity, category, resource tags (-tag-weights), exposure and age; writes priority.json in preprocess mode and a Priority sheet in Excel")
+	riskConfigFile := fs.String("risk-config", "", "Risk score config file (JSON) overriding severity scores, category weights, exposure rules and age factors (implies -risk)")
+	slaEnabled := fs.Bool("sla", false, "Compute remediation due dates from first-seen (-baseline or -history-db history, else the scan's CreatedAt) with default SLAs (CRITICAL 7, HIGH 30, MEDIUM 90, LOW 180 days) and flag overdue findings")
+	slaConfigFile := fs.String("sla-config", "", "SLA config file (JSON) with due days by severity and per-category overrides (implies -sla)")
+	slaAsOf := fs.String("sla-as-of", "", "Date (YYYY-MM-DD or RFC 3339) used to decide overdue findings (default: today)")
+	failOnOverdue := fs.String("fail-on-overdue", "", "Exit with status 3 when overdue findings exist: \"all\" or severities, e.g. \"CRITICAL,HIGH\" (requires -sla)")
+	baselineSpec := fs.String("baseline", "", "Earlier scan JSON files, comma-separated; used as history to compute how long each finding has been open")
+	fs.StringVar(&config.HistoryDB, "history-db", "", "History database created by 'parser ingest'; first-seen dates of the input's ArtifactName are used like -baseline")
+	blameRoot := fs.String("blame", "", "Local git repository root; attaches the last commit, author and date for each violation's line range using git blame (offline, cached per file)")
+	fs.StringVar(&config.SourceRoot, "source-root", "", "Preprocess: scanned repository root; attaches source lines around each violation from the local checkout")
+	fs.IntVar(&config.SourceOptions.Context, "source-context", 3, "Preprocess: lines of context before and after the cause lines for -source-root")
+	sourceHashes := fs.String("source-hashes", "", "Preprocess: sha256sum-format file hashes taken at scan time, used to detect a checkout that differs from the scanned revision (default: compare against the scan's code lines)")
+	sortSpec := fs.String("sort", "", "Sort keys for preprocess JSON and Excel rows: severity, policy, target, line, risk (comma-separated, '-' prefix for descending)")
+	filenameScheme := fs.String("filename-scheme", string(processor.FilenameLegacy), "Preprocess: output filename scheme (legacy: % separators, encoded: reversible ~XX escapes, tree: mirror directory tree)")
+	onCollision := fs.String("on-collision", string(processor.CollisionError), "Preprocess: action when two targets map to the same filename (error, suffix)")
+	fs.IntVar(&config.ChunkBudget, "chunk-budget", 0, "Preprocess: pack/split output into chunk files under this byte/token budget (0 = one file per target)")
+	tokenizerName := fs.String("tokenizer", "bytes", "Preprocess: budget unit for -chunk-budget (bytes, approx: ~4 characters per token)")
+	groupBy := fs.String("group-by", string(processor.GroupByPolicy), "Preprocess: grouping axis inside each target file (policy, resource, service, provider, module, tag:<key>)")
+	fieldSpec := fs.String("fields", "", "Preprocess: field selection per level, e.g. \"policy:+AVDID,+References,-Description;violation:+Code\" (levels: result, target, policy, violation)")
+	renderSpec := fs.String("render", "", "Preprocess: render text (.md) with a builtin template (remediation, policy) or a text/template file instead of JSON")
+	renderScope := fs.String("render-scope", string(render.ScopeTarget), "Preprocess: render unit for -render template files (target, policy)")
+	fs.StringVar(&config.NotifyWebhook, "notify-webhook", "", "Incoming webhook URL to post a scan summary to after writing output (default: $"+notifyWebhookEnv+")")
+	notifyFormat := fs.String("notify-format", string(render.NotifyAuto), "Webhook payload format (auto: teams for Teams/Power Automate hosts, else slack; slack, teams)")
+	notifyTemplate := fs.String("notify-template", "", "text/template file for the notification message (default: builtin template for -notify-format)")
+	fs.StringVar(&config.NotifyOptions.Link, "notify-link", "", "Link to the generated artifacts shown in the notification (e.g. CI job URL)")
+	fs.IntVar(&config.NotifyOptions.Top, "notify-top", 5, "Maximum new findings listed in the notification (new = not in -baseline/-history-db history)")
+	fs.IntVar(&config.NotifyRetry.Attempts, "notify-retries", 3, "Webhook delivery attempts; network errors, 408, 429 and 5xx are retried with exponential backoff")
+	fs.BoolVar(&config.NotifyDryRun, "notify-dry-run", false, "Print the webhook payload to standard output instead of sending it")
+	langCode := fs.String("lang", string(i18n.English), "Output language for reports and messages (en, ko)")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	// 출력 언어 설정
+	lang, err := i18n.Parse(*langCode)
+	if err != nil {
+		return nil, err
+	}
+	config.Lang = lang
+	if setDefaultLang {
+		i18n.SetDefault(lang)
+	}
+
+	// 정렬 기준 파싱
+	config.SortKeys, err = processor.ParseSortKeys(*sortSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	// 정책 카테고리 분류 규칙 로드
+	config.Classifier = processor.DefaultClassifier()
+	if *categoriesFile != "" {
+		if config.Classifier, err = io.ReadClassifier(*categoriesFile); err != nil {
+			return nil, fmt.Errorf("%s: %w", *categoriesFile, err)
+		}
+	}
+
+	// 컴플라이언스 매핑 로드
+	if *complianceSpec != "" {
+		var specs []string
+		for _, spec := range strings.Split(*complianceSpec, ",") {
+			if spec = strings.TrimSpace(spec); spec != "" {
+				specs = append(specs, spec)
+			}
+		}
+		if config.Compliance, err = io.ReadComplianceMappings(specs); err != nil {
+			return nil, err
+		}
+	}
+
+	// 로컬 Rego 정책 메타데이터 로드
+	if *policyDir != "" {
+		if config.PolicyMetadata, err = io.ReadPolicyDir(*policyDir); err != nil {
+			return nil, err
+		}
+	}
+
+	// CODEOWNERS 로드 및 출력 파일 단위 파싱
+	if *codeOwnersFile != "" {
+		if config.CodeOwners, err = io.ReadCodeOwners(*codeOwnersFile); err != nil {
+			return nil, fmt.Errorf("%s: %w", *codeOwnersFile, err)
+		}
+	}
+	switch strings.ToLower(*splitBy) {
+	case "target":
+	case "owner":
+		if config.CodeOwners == nil {
+			return nil, errors.New("-split-by owner requires -codeowners")
+		}
+		config.SplitByOwner = true
+	default:
+		return nil, fmt.Errorf("unknown split unit: %q (supported: target, owner)", *splitBy)
+	}
+
+	// 로컬 .tf 파싱 및 태그 옵션
+	if *terraformRoot != "" {
+		if config.Terraform, err = io.ReadTerraformDir(*terraformRoot); err != nil {
+			return nil, err
+		}
+		for _, skipped := range config.Terraform.Skipped {
+			fmt.Fprintln(os.Stderr, i18n.T("cli.terraform_skipped", skipped))
+		}
+		for _, key := range strings.Split(*tagKeys, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				config.TerraformOptions.Keys = append(config.TerraformOptions.Keys, key)
+			}
+		}
+	} else if *tagFilter != "" || *tagWeights != "" {
+		return nil, errors.New("-tag-filter and -tag-weights require -terraform-root")
+	}
+	if config.TerraformOptions.Filter, err = processor.ParseTagFilter(*tagFilter); err != nil {
+		return nil, err
+	}
+	if config.TerraformOptions.Weights, err = processor.ParseTagWeights(*tagWeights); err != nil {
+		return nil, err
+	}
+
+	// 위험 점수 모델 (-tag-weights 가중치를 태그 배수로 사용)
+	if *riskConfigFile != "" {
+		if config.Risk, err = io.ReadRiskConfig(*riskConfigFile, config.TerraformOptions.Weights); err != nil {
+			return nil, fmt.Errorf("%s: %w", *riskConfigFile, err)
+		}
+	} else if *riskEnabled {
+		if config.Risk, err = processor.NewRiskModel(processor.RiskConfig{}, config.TerraformOptions.Weights); err != nil {
+			return nil, err
+		}
+	}
+	config.BaselineFiles = splitListInternal(*baselineSpec)
+
+	// 조치 기한 정책, 기준일, CI 게이트
+	if *slaConfigFile != "" {
+		if config.SLA, err = io.ReadSLAConfig(*slaConfigFile); err != nil {
+			return nil, fmt.Errorf("%s: %w", *slaConfigFile, err)
+		}
+	} else if *slaEnabled {
+		if config.SLA, err = processor.NewSLAPolicy(processor.SLAConfig{}); err != nil {
+			return nil, err
+		}
+	}
+	config.SLAAsOf = time.Now()
+	if *slaAsOf != "" {
+		if config.SLAAsOf, err = parseDateInternal(*slaAsOf); err != nil {
+			return nil, fmt.Errorf("invalid -sla-as-of: %w", err)
+		}
+	}
+	if *failOnOverdue != "" {
+		if config.SLA == nil {
+			return nil, errors.New("-fail-on-overdue requires -sla or -sla-config")
+		}
+		if config.OverdueGate, err = processor.ParseSLAGate(*failOnOverdue); err != nil {
+			return nil, err
+		}
+	}
+	// 채팅 웹후크 알림 템플릿 및 전송 옵션
+	if config.NotifyWebhook == "" {
+		config.NotifyWebhook = os.Getenv(notifyWebhookEnv)
+	}
+	if config.NotifyWebhook != "" || config.NotifyDryRun {
+		format, err := render.ParseNotifyFormat(*notifyFormat)
+		if err != nil {
+			return nil, err
+		}
+		if config.Notifier, err = render.LoadNotifier(*notifyTemplate, render.DetectNotifyFormat(format, config.NotifyWebhook)); err != nil {
+			return nil, err
+		}
+		if config.NotifyOptions.Top < 0 || config.NotifyRetry.Attempts < 1 {
+			return nil, errors.New("-notify-top must be 0 or greater and -notify-retries must be 1 or greater")
+		}
+		config.NotifyRetry.Backoff = notifyBackoff
+	}
+
+	if (len(config.BaselineFiles) > 0 || config.HistoryDB != "") && config.Risk == nil && config.SLA == nil && config.Notifier == nil {
+		return nil, errors.New("-baseline and -history-db require -risk, -sla or a notification (-notify-webhook, -notify-dry-run)")
+	}
+
+	// git blame 저장소 확인
+	if *blameRoot != "" {
+		if config.Blamer, err = io.NewGitBlamer(*blameRoot); err != nil {
+			return nil, err
+		}
+	}
+
+	// 로컬 소스 스니펫 옵션 검증
+	if config.SourceOptions.Context < 0 {
+		return nil, errors.New("-source-context must be 0 or greater")
+	}
+	if *sourceHashes != "" {
+		if config.SourceRoot == "" {
+			return nil, errors.New("-source-hashes requires -source-root")
+		}
+		if config.SourceOptions.Hashes, err = io.ReadSourceHashes(*sourceHashes); err != nil {
+			return nil, err
+		}
+	}
+
+	// 파일명 생성 방식 및 충돌 처리 방식 파싱
+	if config.FilenameScheme, err = processor.ParseFilenameScheme(*filenameScheme); err != nil {
+		return nil, err
+	}
+	if config.OnCollision, err = processor.ParseCollisionPolicy(*onCollision); err != nil {
+		return nil, err
+	}
+
+	// 압축 파일 출력 형식 파싱
+	if config.Bundle, err = io.ParseBundleFormat(*bundle); err != nil {
+		return nil, err
+	}
+	if config.Bundle != io.BundleNone {
+		// 압축 파일만 전달해도 내용을 알 수 있도록 인덱스 항상 포함
+		config.WriteIndex = true
+	}
+
+	// 청크 토크나이저 선택
+	if config.Tokenizer, err = processor.LookupTokenizer(*tokenizerName); err != nil {
+		return nil, err
+	}
+
+	// 그룹화 기준 파싱 (정책 외 기준은 정책 단위 출력 옵션과 함께 사용할 수 없음)
+	if config.GroupBy, err = processor.ParseGroupBy(*groupBy); err != nil {
+		return nil, err
+	}
+	if config.GroupBy.TagKey() != "" && config.Terraform == nil {
+		return nil, fmt.Errorf("-group-by %s requires -terraform-root", config.GroupBy)
+	}
+	if config.GroupBy != processor.GroupByPolicy &&
+		(config.Normalize || *fieldSpec != "" || *renderSpec != "" || config.ChunkBudget > 0) {
+		return nil, fmt.Errorf("-group-by %s cannot be combined with -normalize, -fields, -render or -chunk-budget", config.GroupBy)
+	}
+
+	// 필드 선택 규칙 파싱 (정규화 출력과는 함께 사용할 수 없음)
+	if *fieldSpec != "" {
+		if config.Normalize {
+			return nil, errors.New("-fields cannot be combined with -normalize")
+		}
+		if config.Projection, err = processor.ParseProjection(*fieldSpec); err != nil {
+			return nil, err
+		}
+	}
+
+	// 렌더링 템플릿 로드
+	if *renderSpec != "" {
+		if config.Renderer, err = render.Load(*renderSpec, render.Scope(*renderScope)); err != nil {
+			return nil, err
+		}
+	}
+
+	// 쉼표로 구분된 여러 입력 파일 분리
+	config.InputFiles = splitListInternal(config.InputFile)
+
+	return config, nil
+}
+
+// parseDateInternal은 "2006-01-02" 또는 RFC 3339 형식의 날짜를 파싱합니다.
+// 날짜만 지정하면 로컬 시간대의 해당 일자로 해석합니다.
+func parseDateInternal(value string) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
 // printUsage는 사용법을 출력합니다.
 func printUsage() {
-	fmt.Println("Usage:")
-	fmt.Println("  parser -input <input.json> -output <output.json> [options]")
+	fmt.Println(i18n.T("cli.usage"))
+	fmt.Println(i18n.T("cli.usage_line"))
 	fmt.Println()
-	fmt.Println("Options:")
+	fmt.Println(i18n.T("cli.options"))
 	flag.PrintDefaults()
 	fmt.Println()
-	fmt.Println("Examples:")
-	fmt.Println("  # Preprocess: group by policy and split by target")
+	fmt.Println(i18n.T("cli.examples"))
+	fmt.Println(i18n.T("cli.example_preprocess"))
 	fmt.Println("  parser -input result-raw.json -output output-dir/ -preprocess -pretty")
 	fmt.Println()
-	fmt.Println("  # Export to Excel file")
+	fmt.Println(i18n.T("cli.example_bundle"))
+	fmt.Println("  parser -input result-raw.json -output preprocess.tar.gz -preprocess -bundle tar.gz")
+	fmt.Println()
+	fmt.Println(i18n.T("cli.example_notify"))
+	fmt.Println("  parser -input result-raw.json -output output-dir/ -preprocess -baseline last-week.json -notify-link \"$CI_JOB_URL\"")
+	fmt.Println()
+	fmt.Println(i18n.T("cli.example_excel"))
 	fmt.Println("  parser -input result-raw.json -output result.xlsx -excel")
+	fmt.Println()
+	fmt.Println(i18n.T("cli.example_trend"))
+	fmt.Println("  parser -input week1.json,week2.json,week3.json -output trend.xlsx -excel")
+	fmt.Println()
+	fmt.Println(i18n.T("cli.example_lang"))
+	fmt.Println("  parser -input result-raw.json -output result.xlsx -excel -lang ko")
+	fmt.Println()
+	fmt.Println(i18n.T("cli.example_history"))
+	fmt.Println("  parser ingest -db history.db -input result-raw.json")
+	fmt.Println("  parser history -db history.db -query open")
+	fmt.Println()
+	fmt.Println(i18n.T("cli.example_serve"))
+	fmt.Println("  parser serve -addr :8080")
+	fmt.Println("  curl -fsS --data-binary @result-raw.json -o result.xlsx 'http://localhost:8080/v1/excel?lang=ko'")
 }
@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"trivy-parser/i18n"
+	"trivy-parser/io"
+	"trivy-parser/processor"
+)
+
+// 하위 명령 이름 (첫 번째 인자)
+const (
+	CommandIngest  = "ingest"
+	CommandHistory = "history"
+)
+
+// IngestConfig는 ingest 명령 설정입니다.
+type IngestConfig struct {
+	DB         string
+	InputFiles []string
+	Classifier *processor.Classifier
+}
+
+// HistoryConfig는 history 명령 설정입니다.
+type HistoryConfig struct {
+	DB       string
+	Query    string
+	Artifact string // 비어 있으면 전체 아티팩트
+	JSON     bool
+	Pretty   bool
+	Output   string // 비어 있으면 표준 출력
+}
+
+// ParseIngestFlags는 ingest 명령 플래그를 파싱하고 검증합니다.
+func ParseIngestFlags(args []string) *IngestConfig {
+	config := &IngestConfig{}
+	fs := flag.NewFlagSet(CommandIngest, flag.ExitOnError)
+
+	fs.StringVar(&config.DB, "db", "", "History database file (SQLite, created if missing) (required)")
+	inputSpec := fs.String("input", "", "Trivy scan JSON files to store, comma-separated (required); scans already stored (same ArtifactName and CreatedAt) are skipped")
+	categoriesFile := fs.String("categories", "", "Policy category rules file (JSON) used to classify stored findings (default: builtin/custom)")
+	langCode := fs.String("lang", string(i18n.English), "Output language for messages (en, ko)")
+	fs.Usage = func() {
+		printCommandUsageInternal(fs, "cli.usage_ingest", "  parser ingest -db history.db -input week1.json,week2.json")
+	}
+
+	fs.Parse(args)
+	setLangInternal(*langCode)
+
+	config.Classifier = processor.DefaultClassifier()
+	if *categoriesFile != "" {
+		var err error
+		if config.Classifier, err = io.ReadClassifier(*categoriesFile); err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("cli.error", fmt.Errorf("%s: %w", *categoriesFile, err)))
+			os.Exit(1)
+		}
+	}
+
+	config.InputFiles = splitListInternal(*inputSpec)
+	if config.DB == "" || len(config.InputFiles) == 0 {
+		fs.Usage()
+		os.Exit(1)
+	}
+	return config
+}
+
+// ParseHistoryFlags는 history 명령 플래그를 파싱하고 검증합니다.
+func ParseHistoryFlags(args []string) *HistoryConfig {
+	config := &HistoryConfig{}
+	fs := flag.NewFlagSet(CommandHistory, flag.ExitOnError)
+
+	fs.StringVar(&config.DB, "db", "", "History database file created by 'parser ingest' (required)")
+	fs.StringVar(&config.Query, "query", processor.HistoryQueryOpen, "Query: trend (findings per scan), first-seen (first/last seen per finding), time-to-fix (days to fix by severity), open (findings in each artifact's latest scan)")
+	fs.StringVar(&config.Artifact, "artifact", "", "Limit to one ArtifactName (default: all artifacts)")
+	format := fs.String("format", "table", "Output format (table, json)")
+	fs.BoolVar(&config.Pretty, "pretty", false, "Format JSON with indentation")
+	fs.StringVar(&config.Output, "output", "", "Output file path (default: standard output)")
+	langCode := fs.String("lang", string(i18n.English), "Output language for messages and table headers (en, ko)")
+	fs.Usage = func() {
+		printCommandUsageInternal(fs, "cli.usage_history", "  parser history -db history.db -query time-to-fix -artifact my-repo")
+	}
+
+	fs.Parse(args)
+	setLangInternal(*langCode)
+
+	switch config.Query {
+	case processor.HistoryQueryTrend, processor.HistoryQueryFirstSeen, processor.HistoryQueryTimeToFix, processor.HistoryQueryOpen:
+	default:
+		fmt.Fprintln(os.Stderr, i18n.T("cli.error", fmt.Errorf("unknown history query: %q (supported: trend, first-seen, time-to-fix, open)", config.Query)))
+		os.Exit(1)
+	}
+	switch strings.ToLower(*format) {
+	case "table":
+	case "json":
+		config.JSON = true
+	default:
+		fmt.Fprintln(os.Stderr, i18n.T("cli.error", fmt.Errorf("unknown history format: %q (supported: table, json)", *format)))
+		os.Exit(1)
+	}
+
+	if config.DB == "" {
+		fs.Usage()
+		os.Exit(1)
+	}
+	return config
+}
+
+// setLangInternal은 출력 언어를 설정합니다.
+func setLangInternal(code string) {
+	lang, err := i18n.Parse(code)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("cli.error", err))
+		os.Exit(1)
+	}
+	i18n.SetDefault(lang)
+}
+
+// splitListInternal은 쉼표로 구분된 목록을 분리합니다 (빈 항목 제외).
+func splitListInternal(spec string) []string {
+	var values []string
+	for _, value := range strings.Split(spec, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// printCommandUsageInternal은 하위 명령 사용법을 출력합니다.
+func printCommandUsageInternal(fs *flag.FlagSet, usageKey, example string) {
+	fmt.Println(i18n.T("cli.usage"))
+	fmt.Println(i18n.T(usageKey))
+	fmt.Println()
+	fmt.Println(i18n.T("cli.options"))
+	fs.SetOutput(os.Stdout)
+	fs.PrintDefaults()
+	fmt.Println()
+	fmt.Println(i18n.T("cli.examples"))
+	fmt.Println(example)
+}
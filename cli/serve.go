@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	stdio "io"
+	"net/url"
+	"os"
+	"runtime"
+	"slices"
+	"sort"
+	"time"
+	"trivy-parser/i18n"
+	"trivy-parser/render"
+)
+
+// CommandServe는 HTTP 변환 서버 하위 명령 이름입니다.
+const CommandServe = "serve"
+
+// requestOptions는 요청 쿼리로 지정할 수 있는 변환 옵션입니다.
+// 서버의 파일/디렉터리 경로를 지정하는 옵션(-categories, -policy-dir, -blame 등)은 serve 시작 시에만 지정할 수 있습니다.
+var requestOptions = map[string]bool{
+	"lang": true, "pretty": true, "sort": true, "index": true, "normalize": true,
+	"group-by": true, "fields": true, "render": true, "render-scope": true,
+	"filename-scheme": true, "on-collision": true, "chunk-budget": true, "tokenizer": true,
+	"split-by": true, "tag-filter": true, "tag-weights": true, "tag-keys": true,
+	"risk": true, "sla": true, "sla-as-of": true, "excel-details": true,
+}
+
+// ServeConfig는 serve 명령 설정입니다.
+type ServeConfig struct {
+	Addr          string
+	MaxUpload     int64         // 요청 본문 최대 크기 (바이트)
+	Timeout       time.Duration // 요청당 변환 제한 시간
+	MaxConcurrent int           // 동시에 처리하는 변환 요청 수
+
+	// serve 시작 시 지정한 변환 옵션 (요청마다 쿼리 옵션과 함께 다시 파싱)
+	args []string
+}
+
+// ParseServeFlags는 serve 명령 플래그를 파싱하고 검증합니다.
+// 변환 옵션(-categories, -risk 등)은 기본 모드와 같으며 모든 요청에 적용됩니다.
+func ParseServeFlags(args []string) *ServeConfig {
+	config := &ServeConfig{args: args}
+	fs := flag.NewFlagSet(CommandServe, flag.ExitOnError)
+	maxUploadMB := registerServeFlagsInternal(fs, config)
+	fs.Usage = func() {
+		printCommandUsageInternal(fs, "cli.usage_serve", "  parser serve -addr :8080 -categories categories.json -max-concurrent 4")
+	}
+
+	// 시작 시 한 번 파싱하여 잘못된 옵션은 바로 종료
+	if _, err := parseConfigInternal(fs, args, true); err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("cli.error", err))
+		os.Exit(1)
+	}
+	if *maxUploadMB <= 0 || config.Timeout <= 0 || config.MaxConcurrent <= 0 {
+		fmt.Fprintln(os.Stderr, i18n.T("cli.error", "-max-upload, -timeout and -max-concurrent must be greater than 0"))
+		os.Exit(1)
+	}
+	config.MaxUpload = *maxUploadMB << 20
+	return config
+}
+
+// registerServeFlagsInternal은 serve 전용 플래그를 등록하고 업로드 최대 크기(MB) 플래그를 반환합니다.
+func registerServeFlagsInternal(fs *flag.FlagSet, config *ServeConfig) *int64 {
+	fs.StringVar(&config.Addr, "addr", ":8080", "Listen address")
+	maxUploadMB := fs.Int64("max-upload", 32, "Maximum request body size in MB; larger uploads are rejected with 413")
+	fs.DurationVar(&config.Timeout, "timeout", time.Minute, "Per-request conversion time limit; slower requests get 503")
+	fs.IntVar(&config.MaxConcurrent, "max-concurrent", runtime.NumCPU(), "Maximum concurrent conversions; extra requests get 429")
+	return maxUploadMB
+}
+
+// RequestConfig는 serve 시작 옵션에 요청 쿼리 옵션(예: ?sort=severity&group-by=resource)을 덮어써 변환 설정을 만듭니다.
+// 요청마다 새로 파싱하므로 요청 간에 상태를 공유하지 않습니다. 기본 출력 언어는 바꾸지 않습니다.
+func (s *ServeConfig) RequestConfig(query url.Values) (*Config, error) {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		if !requestOptions[key] {
+			return nil, fmt.Errorf("option %q cannot be set per request", key)
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// 템플릿 파일은 서버 경로이므로 요청에서는 내장 템플릿만 허용
+	if spec := query.Get("render"); spec != "" && !slices.Contains(render.BuiltinNames(), spec) {
+		return nil, fmt.Errorf("render %q is not a builtin template (%v)", spec, render.BuiltinNames())
+	}
+
+	args := append([]string{}, s.args...)
+	for _, key := range keys {
+		for _, value := range query[key] {
+			args = append(args, "-"+key+"="+value)
+		}
+	}
+
+	fs := flag.NewFlagSet(CommandServe, flag.ContinueOnError)
+	fs.SetOutput(stdio.Discard)
+	registerServeFlagsInternal(fs, &ServeConfig{})
+	return parseConfigInternal(fs, args, false)
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"trivy-parser/processor"
+)
+
+func TestRequestConfig(t *testing.T) {
+	base := []string{"-risk", "-sort=target"}
+	s := &ServeConfig{args: append([]string{}, base...)}
+
+	tests := []struct {
+		name    string
+		query   string
+		wantErr bool
+	}{
+		// 서버 경로를 지정하는 옵션은 요청에서 지정할 수 없음
+		{name: "policy-dir", query: "policy-dir=/etc", wantErr: true},
+		{name: "blame", query: "blame=/", wantErr: true},
+		{name: "categories", query: "categories=/etc/passwd", wantErr: true},
+		{name: "codeowners", query: "codeowners=/etc/passwd", wantErr: true},
+		{name: "terraform-root", query: "terraform-root=/", wantErr: true},
+		{name: "source-root", query: "source-root=/", wantErr: true},
+		{name: "history-db", query: "history-db=/tmp/h.db", wantErr: true},
+		{name: "output", query: "output=/tmp/x", wantErr: true},
+		{name: "serve flag", query: "max-upload=1024", wantErr: true},
+		{name: "rejected with allowed", query: "sort=severity&baseline=/etc/passwd", wantErr: true},
+
+		// 템플릿 파일은 서버 경로이므로 내장 템플릿만 허용
+		{name: "render file", query: "render=/etc/passwd", wantErr: true},
+		{name: "render builtin", query: "render=remediation"},
+
+		{name: "invalid value", query: "group-by=bogus", wantErr: true},
+		{name: "allowed", query: "sort=severity&group-by=resource&pretty=true"},
+		{name: "empty", query: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			config, err := s.RequestConfig(query)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("RequestConfig(%q): expected error", tt.query)
+				}
+			} else if err != nil {
+				t.Errorf("RequestConfig(%q): %v", tt.query, err)
+			} else if config.Risk == nil {
+				t.Errorf("RequestConfig(%q): serve option -risk was not applied", tt.query)
+			}
+			if !reflect.DeepEqual(s.args, base) {
+				t.Fatalf("base args changed: %v", s.args)
+			}
+		})
+	}
+}
+
+func TestRequestConfigOverridesBase(t *testing.T) {
+	s := &ServeConfig{args: []string{"-sort=target", "-group-by=service"}}
+
+	config, err := s.RequestConfig(url.Values{"sort": {"severity"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(config.SortKeys) != 1 || config.SortKeys[0].Field != processor.SortBySeverity {
+		t.Errorf("SortKeys = %+v, want severity from the request", config.SortKeys)
+	}
+	if config.GroupBy != processor.GroupByService {
+		t.Errorf("GroupBy = %q, want serve option %q", config.GroupBy, processor.GroupByService)
+	}
+
+	// 이전 요청의 옵션이 다음 요청에 남지 않음
+	config, err = s.RequestConfig(url.Values{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(config.SortKeys) != 1 || config.SortKeys[0].Field != processor.SortByTarget {
+		t.Errorf("SortKeys = %+v, want serve option target", config.SortKeys)
+	}
+}
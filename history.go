@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"trivy-parser/cli"
+	"trivy-parser/i18n"
+	"trivy-parser/io"
+	"trivy-parser/processor"
+)
+
+// runIngest는 스캔 결과 파일들을 이력 데이터베이스에 저장합니다 (parser ingest).
+func runIngest(config *cli.IngestConfig) {
+	store, err := io.OpenHistoryStore(config.DB, true)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("cli.error", err))
+		os.Exit(1)
+	}
+	defer store.Close()
+
+	for _, path := range config.InputFiles {
+		scan, _, err := io.ReadFile(path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("cli.error", fmt.Errorf("%s: %w", path, err)))
+			os.Exit(1)
+		}
+		inserted, count, err := store.Ingest(scan, path, config.Classifier)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("cli.error", fmt.Errorf("%s: %w", path, err)))
+			os.Exit(1)
+		}
+		if !inserted {
+			fmt.Println(i18n.T("cli.ingest_skipped", path, scan.ArtifactName, scan.CreatedAt))
+			continue
+		}
+		fmt.Println(i18n.T("cli.ingested", path, scan.ArtifactName, scan.CreatedAt, count))
+	}
+}
+
+// runHistory는 이력 데이터베이스를 조회하여 표 또는 JSON으로 출력합니다 (parser history).
+func runHistory(config *cli.HistoryConfig) {
+	store, err := io.OpenHistoryStore(config.DB, false)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("cli.error", err))
+		os.Exit(1)
+	}
+	scans, observations, err := store.Load(config.Artifact)
+	store.Close()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("cli.error", err))
+		os.Exit(1)
+	}
+	if len(scans) == 0 {
+		fmt.Fprintln(os.Stderr, i18n.T("cli.history_empty", config.DB))
+	}
+
+	var result interface{}
+	var table [][]string
+	switch config.Query {
+	case processor.HistoryQueryTrend:
+		trend := processor.HistoryTrend(scans, observations)
+		result, table = trend.Points, trendTableInternal(trend.Points)
+	case processor.HistoryQueryFirstSeen:
+		lifecycles := processor.BuildLifecycles(scans, observations)
+		result, table = lifecycles, lifecycleTableInternal(lifecycles)
+	case processor.HistoryQueryTimeToFix:
+		lifecycles := processor.BuildLifecycles(scans, observations)
+		summaries := processor.SummarizeTimeToFix(lifecycles)
+		result = struct {
+			Summary []processor.TimeToFixSummary `json:"Summary"`
+			Fixed   []processor.FindingLifecycle `json:"Fixed"`
+		}{summaries, fixedLifecyclesInternal(lifecycles)}
+		table = timeToFixTableInternal(summaries)
+	case processor.HistoryQueryOpen:
+		lifecycles := processor.OpenLifecycles(processor.BuildLifecycles(scans, observations))
+		result, table = lifecycles, lifecycleTableInternal(lifecycles)
+	}
+
+	var output string
+	if config.JSON {
+		var data []byte
+		if config.Pretty {
+			data, err = json.MarshalIndent(result, "", "  ")
+		} else {
+			data, err = json.Marshal(result)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("cli.error", fmt.Errorf("%s: %w", i18n.T("err.json_marshal"), err)))
+			os.Exit(1)
+		}
+		output = string(data) + "\n"
+	} else {
+		output = formatTableInternal(table)
+	}
+
+	if config.Output == "" {
+		fmt.Print(output)
+		return
+	}
+	if _, err := io.WriteText(config.Output, output); err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("cli.error", err))
+		os.Exit(1)
+	}
+	fmt.Println(i18n.T("cli.output_history", config.Output))
+}
+
+// trendTableInternal은 스캔별 심각도 집계 표를 만듭니다.
+func trendTableInternal(points []processor.TrendPoint) [][]string {
+	table := [][]string{{
+		i18n.T("excel.header.created_at"), i18n.T("excel.header.artifact"),
+		"CRITICAL", "HIGH", "MEDIUM", "LOW", i18n.T("excel.header.total"),
+	}}
+	for _, point := range points {
+		table = append(table, []string{
+			point.CreatedAt, point.ArtifactName,
+			fmt.Sprint(point.Severity.Critical), fmt.Sprint(point.Severity.High),
+			fmt.Sprint(point.Severity.Medium), fmt.Sprint(point.Severity.Low),
+			fmt.Sprint(point.Total),
+		})
+	}
+	return table
+}
+
+// lifecycleTableInternal은 finding별 최초/최종 발견 표를 만듭니다.
+func lifecycleTableInternal(lifecycles []processor.FindingLifecycle) [][]string {
+	table := [][]string{{
+		i18n.T("excel.header.artifact"), i18n.T("excel.header.target"), i18n.T("excel.header.policy_id"),
+		i18n.T("excel.header.severity"), i18n.T("excel.header.resource"),
+		i18n.T("excel.header.first_seen"), i18n.T("excel.header.last_seen"), i18n.T("history.header.fixed_at"),
+		i18n.T("history.header.days"),
+	}}
+	for _, lifecycle := range lifecycles {
+		table = append(table, []string{
+			lifecycle.ArtifactName, lifecycle.Target, lifecycle.PolicyID,
+			lifecycle.Severity, lifecycle.Resource,
+			scanDateInternal(lifecycle.FirstSeen), scanDateInternal(lifecycle.LastSeen), scanDateInternal(lifecycle.FixedAt),
+			fmt.Sprint(lifecycle.Days),
+		})
+	}
+	return table
+}
+
+// timeToFixTableInternal은 심각도별 조치 소요 일수 표를 만듭니다.
+func timeToFixTableInternal(summaries []processor.TimeToFixSummary) [][]string {
+	table := [][]string{{
+		i18n.T("excel.header.severity"), i18n.T("history.header.fixed"), i18n.T("history.header.open"),
+		i18n.T("history.header.mean_days"), i18n.T("history.header.median_days"), i18n.T("history.header.max_days"),
+	}}
+	for _, summary := range summaries {
+		table = append(table, []string{
+			summary.Severity, fmt.Sprint(summary.Fixed), fmt.Sprint(summary.Open),
+			fmt.Sprint(summary.MeanDays), fmt.Sprint(summary.MedianDays), fmt.Sprint(summary.MaxDays),
+		})
+	}
+	return table
+}
+
+// fixedLifecyclesInternal은 조치된 finding만 반환합니다.
+func fixedLifecyclesInternal(lifecycles []processor.FindingLifecycle) []processor.FindingLifecycle {
+	fixed := []processor.FindingLifecycle{}
+	for _, lifecycle := range lifecycles {
+		if !lifecycle.Open && lifecycle.FixedAt != "" {
+			fixed = append(fixed, lifecycle)
+		}
+	}
+	return fixed
+}
+
+// scanDateInternal은 CreatedAt을 "2006-01-02" 형식으로 표시합니다 (파싱할 수 없으면 그대로).
+func scanDateInternal(createdAt string) string {
+	if t, err := processor.ParseCreatedAt(createdAt); err == nil {
+		return t.Format("2006-01-02")
+	}
+	return createdAt
+}
+
+// formatTableInternal은 첫 행을 헤더로 하는 탭 정렬 표를 만듭니다.
+func formatTableInternal(table [][]string) string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	for _, row := range table {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	w.Flush()
+	return buf.String()
+}
@@ -0,0 +1,75 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Lang은 출력 언어 코드입니다.
+type Lang string
+
+const (
+	English Lang = "en"
+	Korean  Lang = "ko"
+)
+
+// defaultLang은 Lang이 지정되지 않았을 때 사용하는 언어입니다.
+// -lang 도입 전에는 CLI/에러 메시지가 한국어였으므로 기존 출력이 필요하면 -lang ko를 지정해야 합니다 (README 호환성 참고).
+var defaultLang = English
+
+// Supported는 지원하는 언어 목록을 반환합니다.
+func Supported() []Lang {
+	return []Lang{English, Korean}
+}
+
+// Parse는 언어 코드 문자열을 Lang으로 변환합니다.
+// "ko-KR", "en_US" 같은 지역 코드가 붙은 형식도 허용합니다.
+func Parse(code string) (Lang, error) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if i := strings.IndexAny(code, "-_"); i > 0 {
+		code = code[:i]
+	}
+
+	lang := Lang(code)
+	if _, ok := catalog[lang]; !ok {
+		return "", fmt.Errorf("unsupported language: %q (supported: en, ko)", code)
+	}
+	return lang, nil
+}
+
+// SetDefault는 기본 출력 언어를 설정합니다.
+func SetDefault(lang Lang) {
+	if _, ok := catalog[lang]; ok {
+		defaultLang = lang
+	}
+}
+
+// Default는 현재 기본 출력 언어를 반환합니다.
+func Default() Lang {
+	return defaultLang
+}
+
+// T는 기본 언어로 메시지를 조회합니다.
+func T(key string, args ...interface{}) string {
+	return defaultLang.T(key, args...)
+}
+
+// T는 해당 언어로 메시지를 조회하고 args로 포맷팅합니다.
+// 빈 Lang은 기본 언어를 사용하며, 번역이 없으면 영어, 그래도 없으면 키를 그대로 반환합니다.
+func (l Lang) T(key string, args ...interface{}) string {
+	if l == "" {
+		l = defaultLang
+	}
+
+	msg, ok := catalog[l][key]
+	if !ok {
+		if msg, ok = catalog[English][key]; !ok {
+			msg = key
+		}
+	}
+
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
@@ -0,0 +1,326 @@
+package i18n
+
+// catalog는 언어별 메시지 카탈로그입니다.
+// 키 규칙: <영역>.<항목> (cli: CLI 출력, err: 에러, excel: Excel 시트/헤더/라벨)
+var catalog = map[Lang]map[string]string{
+	English: {
+		// CLI 출력
+		"cli.input":              "Input:  %s (%.2f MB)",
+		"cli.output_excel":       "Output: %s (Excel format)",
+		"cli.output_files":       "Output: %d files -> %s",
+		"cli.output_index":       "Index:  %s",
+		"cli.output_bundle":      "Bundle: %s (%s, %.2f MB)",
+		"cli.notify_sent":        "Notification: sent %s webhook (%d findings, %d new)",
+		"cli.notify_failed":      "Warning: notification not sent: %v",
+		"cli.notify_dry_run":     "Notification (dry run, %s payload):",
+		"cli.output_catalog":     "Policy catalog: %s",
+		"cli.size_reduction":     "Size reduction: %.1f%% (%.2f MB -> %.2f MB)",
+		"cli.error":              "Error: %v",
+		"cli.error_target":       "Error (%s): %v",
+		"cli.no_tf_files":        "Error: No .tf files found to process",
+		"cli.no_mode":            "Error: Please specify either -excel or -preprocess mode",
+		"cli.multi_input_excel":  "Error: Multiple input files are only supported in -excel mode",
+		"cli.collision_resolved": "Warning: %s collides with %s, written as %s",
+		"cli.source_missing":     "Warning: %s not found under %s, source snippets skipped",
+		"cli.source_mismatch":    "Warning: %s differs from the scanned revision, source snippets may be misaligned",
+		"cli.output_unowned":     "Unowned report: %s (%d findings)",
+		"cli.output_priority":    "Priority list: %s (%d findings)",
+		"cli.output_overdue":     "Overdue report: %s (%d of %d findings past SLA)",
+		"cli.sla_gate_failed":    "SLA gate failed: %d overdue findings (%s)",
+		"cli.blame_failed":       "Warning: git blame failed for %s (not tracked?), blame skipped",
+		"cli.terraform_skipped":  "Warning: skipped unparsable Terraform file: %v",
+		"cli.ingested":           "Stored %s: %s (%s), %d findings",
+		"cli.ingest_skipped":     "Skipped %s: scan already stored (%s, %s)",
+		"cli.history_empty":      "Warning: no scans stored in %s",
+		"cli.output_history":     "Output: %s",
+		"cli.serve_listening":    "Listening on %s (POST /v1/preprocess, POST /v1/excel, GET /healthz)",
+		"cli.serve_request":      "%s %s -> %d (%s)",
+		"cli.usage":              "Usage:",
+		"cli.usage_line":         "  parser -input <input.json> -output <output.json> [options]",
+		"cli.usage_ingest":       "  parser ingest -db <history.db> -input <scan.json>[,<scan.json>...] [options]",
+		"cli.usage_history":      "  parser history -db <history.db> -query <trend|first-seen|time-to-fix|open> [options]",
+		"cli.usage_serve":        "  parser serve [-addr :8080] [-max-upload 32] [-timeout 1m] [-max-concurrent N] [conversion options]",
+		"cli.options":            "Options:",
+		"cli.examples":           "Examples:",
+		"cli.example_preprocess": "  # Preprocess: group by policy and split by target",
+		"cli.example_bundle":     "  # Preprocess into a single archive (zip, tar.gz)",
+		"cli.example_notify":     "  # Post a severity summary to Slack/Teams after preprocessing (webhook URL from $TRIVY_PARSER_WEBHOOK_URL)",
+		"cli.example_excel":      "  # Export to Excel file",
+		"cli.example_trend":      "  # Export multiple weekly scans with a Trend sheet",
+		"cli.example_lang":       "  # Korean report",
+		"cli.example_history":    "  # Store scans in a local history database and list open findings",
+		"cli.example_serve":      "  # Serve conversions over HTTP",
+
+		// 에러
+		"err.file_read":         "failed to read file",
+		"err.json_parse":        "failed to parse JSON",
+		"err.json_marshal":      "failed to generate JSON",
+		"err.file_write":        "failed to write file",
+		"err.notify_send":       "failed to send webhook notification",
+		"err.style_create":      "failed to create %s style",
+		"err.sheet_create":      "failed to create %s sheet",
+		"err.sheet_write":       "failed to write %s sheet",
+		"err.column_width":      "failed to set column width",
+		"err.chart_create":      "failed to create chart",
+		"err.excel_save":        "failed to save Excel file",
+		"err.history_open":      "failed to open history database",
+		"err.history_ingest":    "failed to store scan in history database",
+		"err.history_query":     "failed to query history database",
+		"err.serve_busy":        "server busy: too many concurrent conversions, retry later",
+		"err.serve_timeout":     "conversion exceeded the %s time limit",
+		"err.serve_no_file":     "multipart upload requires at least one \"file\" field",
+		"err.serve_multi_input": "multiple scans are supported only by /v1/excel",
+
+		// Excel 시트 이름
+		"excel.sheet.custom":     "Custom",
+		"excel.sheet.builtin":    "Built-in",
+		"excel.sheet.trend":      "Trend",
+		"excel.sheet.modules":    "Modules",
+		"excel.sheet.compliance": "Compliance",
+		"excel.sheet.controls":   "Controls",
+		"excel.sheet.unowned":    "Unowned",
+		"excel.sheet.tags":       "Tags",
+		"excel.sheet.priority":   "Priority",
+		"excel.sheet.overdue":    "Overdue",
+
+		// Excel 헤더
+		"excel.header.target":               "Target",
+		"excel.header.title":                "Title",
+		"excel.header.resource":             "Resource",
+		"excel.header.severity":             "Severity",
+		"excel.header.resolution":           "Resolution",
+		"excel.header.start_line":           "StartLine",
+		"excel.header.end_line":             "EndLine",
+		"excel.header.primary_url":          "PrimaryURL",
+		"excel.header.message":              "Message",
+		"excel.header.description":          "Description",
+		"excel.header.code":                 "Code",
+		"excel.header.first_seen":           "FirstSeen",
+		"excel.header.last_seen":            "LastSeen",
+		"excel.header.created_at":           "CreatedAt",
+		"excel.header.artifact":             "ArtifactName",
+		"excel.header.total":                "Total",
+		"excel.header.module":               "Module",
+		"excel.header.root_file":            "RootFile",
+		"excel.header.targets":              "Targets",
+		"excel.header.occurrences":          "Occurrences",
+		"excel.header.compliance":           "Compliance",
+		"excel.header.code_owners":          "Code Owners",
+		"excel.header.module_dir":           "Module Dir",
+		"excel.header.risk_weight":          "Risk Weight",
+		"excel.header.tags":                 "Tags",
+		"excel.header.tag_key":              "Tag",
+		"excel.header.tag_value":            "Value",
+		"excel.header.last_commit":          "Last Commit",
+		"excel.header.author":               "Author",
+		"excel.header.commit_date":          "Commit Date",
+		"excel.header.owner":                "Owner",
+		"excel.header.risk_rationale":       "Risk Rationale",
+		"excel.header.remediation_examples": "Remediation Examples",
+		"excel.header.related_resources":    "Related Resources",
+		"excel.header.framework":            "Framework",
+		"excel.header.name":                 "Name",
+		"excel.header.controls":             "Controls",
+		"excel.header.passing":              "Passing",
+		"excel.header.failing":              "Failing",
+		"excel.header.not_evaluated":        "NotEvaluated",
+		"excel.header.pass_rate":            "PassRate(%)",
+		"excel.header.control":              "Control",
+		"excel.header.status":               "Status",
+		"excel.header.findings":             "Findings",
+		"excel.header.policies":             "Policies",
+		"excel.header.rank":                 "Rank",
+		"excel.header.risk_score":           "Risk Score",
+		"excel.header.category":             "Category",
+		"excel.header.policy_id":            "PolicyID",
+		"excel.header.severity_score":       "Severity Score",
+		"excel.header.category_weight":      "Category Weight",
+		"excel.header.tag_weight":           "Tag Weight",
+		"excel.header.exposure":             "Exposure",
+		"excel.header.age_days":             "Age (days)",
+		"excel.header.age_weight":           "Age Weight",
+		"excel.header.due_date":             "Due Date",
+		"excel.header.days_remaining":       "Days Left",
+		"excel.header.days_overdue":         "Days Overdue",
+
+		// 이력 조회 표 헤더
+		"history.header.fixed_at":    "FixedAt",
+		"history.header.days":        "Days",
+		"history.header.fixed":       "Fixed",
+		"history.header.open":        "Open",
+		"history.header.mean_days":   "Mean (days)",
+		"history.header.median_days": "Median (days)",
+		"history.header.max_days":    "Max (days)",
+
+		// Excel 요약 라벨
+		"excel.chart.severity": "Findings by Severity",
+		"excel.chart.category": "Findings by Category",
+
+		// 스타일 이름 (에러 메시지용)
+		"style.header":   "header",
+		"style.red_text": "red text",
+		"style.wrap":     "wrap text",
+		"style.code":     "code",
+		"style.overdue":  "overdue",
+	},
+	Korean: {
+		// CLI 출력
+		"cli.input":              "입력:  %s (%.2f MB)",
+		"cli.output_excel":       "출력: %s (Excel 형식)",
+		"cli.output_files":       "출력: %d개 파일 -> %s",
+		"cli.output_index":       "인덱스: %s",
+		"cli.output_bundle":      "압축 파일: %s (%s, %.2f MB)",
+		"cli.notify_sent":        "알림: %s 웹후크 전송 완료 (검출 %d건, 신규 %d건)",
+		"cli.notify_failed":      "경고: 알림 전송 실패: %v",
+		"cli.notify_dry_run":     "알림 (dry run, %s 페이로드):",
+		"cli.output_catalog":     "정책 카탈로그: %s",
+		"cli.size_reduction":     "용량 감소: %.1f%% (%.2f MB -> %.2f MB)",
+		"cli.error":              "오류: %v",
+		"cli.error_target":       "오류 (%s): %v",
+		"cli.no_tf_files":        "오류: 처리할 .tf 파일이 없습니다",
+		"cli.no_mode":            "오류: -excel 또는 -preprocess 모드 중 하나를 지정하세요",
+		"cli.multi_input_excel":  "오류: 여러 입력 파일은 -excel 모드에서만 지원합니다",
+		"cli.collision_resolved": "경고: %s 파일명이 %s와 충돌하여 %s로 저장했습니다",
+		"cli.source_missing":     "경고: %s 파일이 %s에 없어 소스 스니펫을 생략했습니다",
+		"cli.source_mismatch":    "경고: %s 파일이 스캔한 리비전과 달라 소스 스니펫의 라인이 어긋날 수 있습니다",
+		"cli.output_unowned":     "담당자 없음 리포트: %s (%d건)",
+		"cli.output_priority":    "우선순위 목록: %s (%d건)",
+		"cli.output_overdue":     "기한 초과 리포트: %s (%d/%d건 SLA 초과)",
+		"cli.sla_gate_failed":    "SLA 게이트 실패: 기한 초과 %d건 (%s)",
+		"cli.blame_failed":       "경고: %s 파일의 git blame에 실패하여 (추적되지 않는 파일?) 생략했습니다",
+		"cli.terraform_skipped":  "경고: 파싱할 수 없는 Terraform 파일을 건너뛰었습니다: %v",
+		"cli.ingested":           "저장: %s: %s (%s), %d건",
+		"cli.ingest_skipped":     "건너뜀: %s: 이미 저장된 스캔 (%s, %s)",
+		"cli.history_empty":      "경고: %s에 저장된 스캔이 없습니다",
+		"cli.output_history":     "출력: %s",
+		"cli.serve_listening":    "%s에서 대기 중 (POST /v1/preprocess, POST /v1/excel, GET /healthz)",
+		"cli.serve_request":      "%s %s -> %d (%s)",
+		"cli.usage":              "사용법:",
+		"cli.usage_line":         "  parser -input <input.json> -output <output.json> [옵션]",
+		"cli.usage_ingest":       "  parser ingest -db <history.db> -input <scan.json>[,<scan.json>...] [옵션]",
+		"cli.usage_history":      "  parser history -db <history.db> -query <trend|first-seen|time-to-fix|open> [옵션]",
+		"cli.usage_serve":        "  parser serve [-addr :8080] [-max-upload 32] [-timeout 1m] [-max-concurrent N] [변환 옵션]",
+		"cli.options":            "옵션:",
+		"cli.examples":           "예시:",
+		"cli.example_preprocess": "  # Preprocess: 정책별 그룹화 후 타겟별 분리",
+		"cli.example_bundle":     "  # Preprocess 결과를 압축 파일 하나로 저장 (zip, tar.gz)",
+		"cli.example_notify":     "  # preprocess 후 Slack/Teams로 심각도 요약 전송 (웹후크 URL은 $TRIVY_PARSER_WEBHOOK_URL)",
+		"cli.example_excel":      "  # Excel 파일로 내보내기",
+		"cli.example_trend":      "  # 주간 스캔 여러 개를 추이(Trend) 시트와 함께 내보내기",
+		"cli.example_lang":       "  # 한국어 리포트",
+		"cli.example_history":    "  # 로컬 이력 데이터베이스에 스캔 저장 후 미조치 finding 조회",
+		"cli.example_serve":      "  # HTTP 변환 서버 실행",
+
+		// 에러
+		"err.file_read":         "파일 읽기 실패",
+		"err.json_parse":        "JSON 파싱 실패",
+		"err.json_marshal":      "JSON 생성 실패",
+		"err.file_write":        "파일 저장 실패",
+		"err.notify_send":       "웹후크 알림 전송 실패",
+		"err.style_create":      "%s 스타일 생성 실패",
+		"err.sheet_create":      "%s 시트 생성 실패",
+		"err.sheet_write":       "%s 시트 작성 실패",
+		"err.column_width":      "컬럼 너비 설정 실패",
+		"err.chart_create":      "차트 생성 실패",
+		"err.excel_save":        "Excel 파일 저장 실패",
+		"err.history_open":      "이력 데이터베이스 열기 실패",
+		"err.history_ingest":    "이력 데이터베이스 저장 실패",
+		"err.history_query":     "이력 데이터베이스 조회 실패",
+		"err.serve_busy":        "동시 변환 요청이 많습니다. 잠시 후 다시 시도하세요",
+		"err.serve_timeout":     "변환 제한 시간(%s)을 초과했습니다",
+		"err.serve_no_file":     "multipart 업로드에는 \"file\" 필드가 1개 이상 필요합니다",
+		"err.serve_multi_input": "여러 스캔 업로드는 /v1/excel에서만 지원합니다",
+
+		// Excel 시트 이름
+		"excel.sheet.custom":     "커스텀",
+		"excel.sheet.builtin":    "기본 정책",
+		"excel.sheet.trend":      "추이",
+		"excel.sheet.modules":    "모듈",
+		"excel.sheet.compliance": "컴플라이언스",
+		"excel.sheet.controls":   "통제 항목",
+		"excel.sheet.unowned":    "담당자 없음",
+		"excel.sheet.tags":       "태그",
+		"excel.sheet.priority":   "우선순위",
+		"excel.sheet.overdue":    "기한 초과",
+
+		// Excel 헤더
+		"excel.header.target":               "대상 파일",
+		"excel.header.title":                "제목",
+		"excel.header.resource":             "리소스",
+		"excel.header.severity":             "심각도",
+		"excel.header.resolution":           "조치 방법",
+		"excel.header.start_line":           "시작 라인",
+		"excel.header.end_line":             "종료 라인",
+		"excel.header.primary_url":          "참고 URL",
+		"excel.header.message":              "메시지",
+		"excel.header.description":          "설명",
+		"excel.header.code":                 "코드",
+		"excel.header.first_seen":           "최초 발견일",
+		"excel.header.last_seen":            "최종 발견일",
+		"excel.header.created_at":           "스캔 일시",
+		"excel.header.artifact":             "아티팩트",
+		"excel.header.total":                "합계",
+		"excel.header.module":               "모듈",
+		"excel.header.root_file":            "루트 파일",
+		"excel.header.targets":              "대상 파일 목록",
+		"excel.header.occurrences":          "호출 체인",
+		"excel.header.compliance":           "통제 항목 참조",
+		"excel.header.code_owners":          "코드 담당자",
+		"excel.header.module_dir":           "모듈 디렉터리",
+		"excel.header.risk_weight":          "위험 가중치",
+		"excel.header.tags":                 "태그",
+		"excel.header.tag_key":              "태그",
+		"excel.header.tag_value":            "값",
+		"excel.header.last_commit":          "마지막 커밋",
+		"excel.header.author":               "작성자",
+		"excel.header.commit_date":          "커밋 일시",
+		"excel.header.owner":                "담당 팀",
+		"excel.header.risk_rationale":       "위험 근거",
+		"excel.header.remediation_examples": "조치 예시",
+		"excel.header.related_resources":    "관련 링크",
+		"excel.header.framework":            "프레임워크",
+		"excel.header.name":                 "이름",
+		"excel.header.controls":             "통제 항목 수",
+		"excel.header.passing":              "충족",
+		"excel.header.failing":              "미충족",
+		"excel.header.not_evaluated":        "미평가",
+		"excel.header.pass_rate":            "충족률(%)",
+		"excel.header.control":              "통제 항목",
+		"excel.header.status":               "상태",
+		"excel.header.findings":             "검출 수",
+		"excel.header.policies":             "정책",
+		"excel.header.rank":                 "순위",
+		"excel.header.risk_score":           "위험 점수",
+		"excel.header.category":             "카테고리",
+		"excel.header.policy_id":            "정책 ID",
+		"excel.header.severity_score":       "심각도 점수",
+		"excel.header.category_weight":      "카테고리 배수",
+		"excel.header.tag_weight":           "태그 가중치",
+		"excel.header.exposure":             "노출 배수",
+		"excel.header.age_days":             "경과일",
+		"excel.header.age_weight":           "경과 배수",
+		"excel.header.due_date":             "조치 기한",
+		"excel.header.days_remaining":       "남은 일수",
+		"excel.header.days_overdue":         "초과 일수",
+
+		// 이력 조회 표 헤더
+		"history.header.fixed_at":    "조치일",
+		"history.header.days":        "경과 일수",
+		"history.header.fixed":       "조치",
+		"history.header.open":        "미조치",
+		"history.header.mean_days":   "평균 (일)",
+		"history.header.median_days": "중앙값 (일)",
+		"history.header.max_days":    "최대 (일)",
+
+		// Excel 요약 라벨
+		"excel.chart.severity": "심각도별 검출 추이",
+		"excel.chart.category": "정책 유형별 검출 추이",
+
+		// 스타일 이름 (에러 메시지용)
+		"style.header":   "헤더",
+		"style.red_text": "빨간색 텍스트",
+		"style.wrap":     "줄바꿈",
+		"style.code":     "코드",
+		"style.overdue":  "기한 초과",
+	},
+}
@@ -2,51 +2,198 @@ package io
 
 import (
 	"fmt"
+	stdio "io"
 	"strings"
+	"trivy-parser/i18n"
 	"trivy-parser/processor"
 
 	"github.com/xuri/excelize/v2"
 )
 
+// ExcelOptions는 Excel 출력 옵션을 담습니다.
+type ExcelOptions struct {
+	// Details가 true이면 Message, Description, Code 컬럼을 추가로 출력합니다.
+	Details bool
+
+	// Lang은 시트 이름, 헤더, 라벨의 출력 언어입니다 (빈 값이면 기본 언어).
+	Lang i18n.Lang
+}
+
+// excelStyles는 시트 작성에 사용하는 스타일 ID를 담습니다.
+type excelStyles struct {
+	header   int
+	redText  int
+	wrapText int
+	code     int
+	overdue  int
+}
+
+// excelColumn은 시트의 한 컬럼 정의입니다.
+// header는 메시지 카탈로그 키이며, style이 nil이 아니면 셀마다 스타일을 결정합니다 (0이면 스타일 미적용).
+type excelColumn struct {
+	header string
+	width  float64
+	value  func(row processor.ExcelRow) interface{}
+	style  func(row processor.ExcelRow) int
+}
+
 // WriteExcel은 Excel 데이터를 Excel 파일로 저장합니다.
-// Custom 정책과 Built-in 정책을 각각 다른 시트에 저장합니다.
-func WriteExcel(filename string, data *processor.ExcelData) error {
+// 정책 카테고리(기본: Custom, Built-in)마다 시트를 하나씩 생성합니다.
+// 여러 스캔을 입력한 경우 Trend 시트를 추가로 생성합니다.
+func WriteExcel(filename string, data *processor.ExcelData, opts ExcelOptions) error {
+	f, err := buildExcelInternal(data, opts)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := f.SaveAs(filename); err != nil {
+		return fmt.Errorf("%s: %w", opts.Lang.T("err.excel_save"), err)
+	}
+	return nil
+}
+
+// WriteExcelTo는 Excel 데이터를 파일 대신 w에 .xlsx 형식으로 기록합니다 (HTTP 응답 등).
+func WriteExcelTo(w stdio.Writer, data *processor.ExcelData, opts ExcelOptions) error {
+	f, err := buildExcelInternal(data, opts)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := f.WriteTo(w); err != nil {
+		return fmt.Errorf("%s: %w", opts.Lang.T("err.excel_save"), err)
+	}
+	return nil
+}
+
+// buildExcelInternal은 Excel 데이터로 시트를 모두 작성한 통합 문서를 만듭니다.
+func buildExcelInternal(data *processor.ExcelData, opts ExcelOptions) (*excelize.File, error) {
 	f := excelize.NewFile()
-	defer func() {
-		if err := f.Close(); err != nil {
-			fmt.Println(err)
+	if err := writeWorkbookInternal(f, data, opts); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+func writeWorkbookInternal(f *excelize.File, data *processor.ExcelData, opts ExcelOptions) error {
+	l := opts.Lang
+	styles, err := newExcelStylesInternal(f, l)
+	if err != nil {
+		return err
+	}
+	columns := excelColumnsInternal(data, opts, styles)
+
+	// 정책 카테고리별 시트 생성 (첫 시트는 기본 시트 이름 변경)
+	for i, category := range data.Categories {
+		sheet := categorySheetNameInternal(category, l)
+		if i == 0 {
+			f.SetSheetName("Sheet1", sheet)
+		} else if _, err := f.NewSheet(sheet); err != nil {
+			return fmt.Errorf("%s: %w", l.T("err.sheet_create", sheet), err)
 		}
-	}()
+		if err := writeExcelSheet(f, sheet, category.Rows, columns, styles, l); err != nil {
+			return fmt.Errorf("%s: %w", l.T("err.sheet_write", sheet), err)
+		}
+	}
 
-	// Custom 시트 생성 (먼저 생성)
-	customSheet := "Custom"
-	f.SetSheetName("Sheet1", customSheet)
-	if err := writeExcelSheet(f, customSheet, data.CustomRows); err != nil {
-		return fmt.Errorf("Custom 시트 작성 실패: %w", err)
+	// Priority 시트 생성 (-risk 옵션 사용 시)
+	if data.Priority != nil {
+		prioritySheet := l.T("excel.sheet.priority")
+		if _, err := f.NewSheet(prioritySheet); err != nil {
+			return fmt.Errorf("%s: %w", l.T("err.sheet_create", prioritySheet), err)
+		}
+		if err := writePrioritySheet(f, prioritySheet, data, styles, l); err != nil {
+			return fmt.Errorf("%s: %w", l.T("err.sheet_write", prioritySheet), err)
+		}
 	}
 
-	// Built-in 시트 생성
-	builtinSheet := "Built-in"
-	_, err := f.NewSheet(builtinSheet)
-	if err != nil {
-		return fmt.Errorf("Built-in 시트 생성 실패: %w", err)
+	// Overdue 시트 생성 (-sla 옵션 사용 시)
+	if data.SLA != nil {
+		overdueSheet := l.T("excel.sheet.overdue")
+		if _, err := f.NewSheet(overdueSheet); err != nil {
+			return fmt.Errorf("%s: %w", l.T("err.sheet_create", overdueSheet), err)
+		}
+		if err := writeOverdueSheet(f, overdueSheet, data, styles, l); err != nil {
+			return fmt.Errorf("%s: %w", l.T("err.sheet_write", overdueSheet), err)
+		}
 	}
-	if err := writeExcelSheet(f, builtinSheet, data.BuiltinRows); err != nil {
-		return fmt.Errorf("Built-in 시트 작성 실패: %w", err)
+
+	// Compliance 시트 생성 (-compliance 옵션 사용 시)
+	if data.Compliance != nil {
+		complianceSheet := l.T("excel.sheet.compliance")
+		if _, err := f.NewSheet(complianceSheet); err != nil {
+			return fmt.Errorf("%s: %w", l.T("err.sheet_create", complianceSheet), err)
+		}
+		controlsSheet := l.T("excel.sheet.controls")
+		if _, err := f.NewSheet(controlsSheet); err != nil {
+			return fmt.Errorf("%s: %w", l.T("err.sheet_create", controlsSheet), err)
+		}
+		if err := writeComplianceSheets(f, complianceSheet, controlsSheet, data.Compliance, styles, l); err != nil {
+			return fmt.Errorf("%s: %w", l.T("err.sheet_write", complianceSheet), err)
+		}
 	}
 
-	// 파일 저장
-	if err := f.SaveAs(filename); err != nil {
-		return fmt.Errorf("Excel 파일 저장 실패: %w", err)
+	// Unowned 시트 생성 (-codeowners 옵션 사용 시)
+	if data.Unowned != nil {
+		unownedSheet := l.T("excel.sheet.unowned")
+		if _, err := f.NewSheet(unownedSheet); err != nil {
+			return fmt.Errorf("%s: %w", l.T("err.sheet_create", unownedSheet), err)
+		}
+		if err := writeUnownedSheet(f, unownedSheet, data.Unowned, styles, l); err != nil {
+			return fmt.Errorf("%s: %w", l.T("err.sheet_write", unownedSheet), err)
+		}
+	}
+
+	// Tags 시트 생성 (-terraform-root 옵션 사용 시)
+	if len(data.TagSummary) > 0 {
+		tagsSheet := l.T("excel.sheet.tags")
+		if _, err := f.NewSheet(tagsSheet); err != nil {
+			return fmt.Errorf("%s: %w", l.T("err.sheet_create", tagsSheet), err)
+		}
+		if err := writeTagsSheet(f, tagsSheet, data.TagSummary, styles, l); err != nil {
+			return fmt.Errorf("%s: %w", l.T("err.sheet_write", tagsSheet), err)
+		}
+	}
+
+	// Modules 시트 생성 (모듈 안에서 검출된 finding이 있는 경우)
+	if data.Modules != nil {
+		modulesSheet := l.T("excel.sheet.modules")
+		if _, err := f.NewSheet(modulesSheet); err != nil {
+			return fmt.Errorf("%s: %w", l.T("err.sheet_create", modulesSheet), err)
+		}
+		if err := writeModulesSheet(f, modulesSheet, data, styles, l); err != nil {
+			return fmt.Errorf("%s: %w", l.T("err.sheet_write", modulesSheet), err)
+		}
+	}
+
+	// Trend 시트 생성 (여러 스캔 입력 시)
+	if data.Trend != nil {
+		trendSheet := l.T("excel.sheet.trend")
+		if _, err := f.NewSheet(trendSheet); err != nil {
+			return fmt.Errorf("%s: %w", l.T("err.sheet_create", trendSheet), err)
+		}
+		if err := writeTrendSheet(f, trendSheet, data, styles, l); err != nil {
+			return fmt.Errorf("%s: %w", l.T("err.sheet_write", trendSheet), err)
+		}
 	}
 
 	return nil
 }
 
-// writeExcelSheet는 특정 시트에 데이터를 작성합니다.
-func writeExcelSheet(f *excelize.File, sheetName string, rows []processor.ExcelRow) error {
+// categorySheetNameInternal은 카테고리의 시트 이름을 반환합니다.
+func categorySheetNameInternal(category processor.ExcelCategory, l i18n.Lang) string {
+	return processor.CategorySheetName(category.Name, category.Sheet, l)
+}
+
+// newExcelStylesInternal은 시트 작성에 필요한 스타일을 생성합니다.
+func newExcelStylesInternal(f *excelize.File, l i18n.Lang) (*excelStyles, error) {
+	styles := &excelStyles{}
+	var err error
+
 	// 헤더 스타일 정의 (Bold + 노란색 배경)
-	headerStyle, err := f.NewStyle(&excelize.Style{
+	styles.header, err = f.NewStyle(&excelize.Style{
 		Font: &excelize.Font{
 			Bold: true,
 		},
@@ -57,47 +204,661 @@ func writeExcelSheet(f *excelize.File, sheetName string, rows []processor.ExcelR
 		},
 	})
 	if err != nil {
-		return fmt.Errorf("헤더 스타일 생성 실패: %w", err)
+		return nil, fmt.Errorf("%s: %w", l.T("err.style_create", l.T("style.header")), err)
 	}
 
 	// 빨간색 텍스트 스타일 정의 (CRITICAL, HIGH용)
-	redTextStyle, err := f.NewStyle(&excelize.Style{
+	styles.redText, err = f.NewStyle(&excelize.Style{
 		Font: &excelize.Font{
 			Color: "#FF0000", // 빨간색
 		},
 	})
 	if err != nil {
-		return fmt.Errorf("빨간색 텍스트 스타일 생성 실패: %w", err)
+		return nil, fmt.Errorf("%s: %w", l.T("err.style_create", l.T("style.red_text")), err)
 	}
 
+	// 줄바꿈 텍스트 스타일 정의 (상세 컬럼용)
+	styles.wrapText, err = f.NewStyle(&excelize.Style{
+		Alignment: &excelize.Alignment{
+			WrapText: true,
+			Vertical: "top",
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", l.T("err.style_create", l.T("style.wrap")), err)
+	}
+
+	// 코드 스니펫 스타일 정의 (고정폭 글꼴 + 줄바꿈)
+	styles.code, err = f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{
+			Family: "Consolas",
+		},
+		Alignment: &excelize.Alignment{
+			WrapText: true,
+			Vertical: "top",
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", l.T("err.style_create", l.T("style.code")), err)
+	}
+
+	// SLA 초과 스타일 정의 (연한 빨간색 배경 + 진한 빨간색 텍스트)
+	styles.overdue, err = f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{
+			Bold:  true,
+			Color: "#9C0006",
+		},
+		Fill: excelize.Fill{
+			Type:    "pattern",
+			Color:   []string{"#FFC7CE"},
+			Pattern: 1,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", l.T("err.style_create", l.T("style.overdue")), err)
+	}
+
+	return styles, nil
+}
+
+// excelColumnsInternal은 옵션에 따라 출력할 컬럼 목록을 구성합니다.
+func excelColumnsInternal(data *processor.ExcelData, opts ExcelOptions, styles *excelStyles) []excelColumn {
+	fixed := func(style int) func(processor.ExcelRow) int {
+		return func(processor.ExcelRow) int { return style }
+	}
+
+	columns := []excelColumn{
+		{header: "excel.header.target", value: func(r processor.ExcelRow) interface{} { return r.Target }},
+		{header: "excel.header.title", value: func(r processor.ExcelRow) interface{} { return r.Title }},
+		{header: "excel.header.resource", value: func(r processor.ExcelRow) interface{} { return r.Resource }},
+	}
+
+	// 담당자 컬럼 (-codeowners 옵션 사용 시)
+	if data.CodeOwners {
+		columns = append(columns, excelColumn{header: "excel.header.code_owners", width: 24,
+			value: func(r processor.ExcelRow) interface{} { return r.CodeOwners }})
+	}
+
+	// 리소스 태그 컬럼 (-terraform-root 옵션 사용 시, -tag-keys의 키마다 1개)
+	if data.TagKeys != nil {
+		for _, key := range data.TagKeys {
+			key := key
+			columns = append(columns, excelColumn{header: key, width: 16,
+				value: func(r processor.ExcelRow) interface{} { return r.Tags[key] }})
+		}
+		columns = append(columns, excelColumn{header: "excel.header.module_dir", width: 20,
+			value: func(r processor.ExcelRow) interface{} { return r.ModuleDir }})
+	}
+	if data.RiskWeights {
+		columns = append(columns, excelColumn{header: "excel.header.risk_weight", width: 12,
+			value: func(r processor.ExcelRow) interface{} { return r.RiskWeight }})
+	}
+
+	// 위험 점수 컬럼 (-risk 옵션 사용 시)
+	if data.Priority != nil {
+		columns = append(columns, excelColumn{header: "excel.header.risk_score", width: 12,
+			value: func(r processor.ExcelRow) interface{} {
+				if r.Risk == nil {
+					return nil
+				}
+				return r.Risk.Score
+			}})
+	}
+
+	// 조치 기한 컬럼 (-sla 옵션 사용 시, 기한 초과 셀 강조)
+	if data.SLA != nil {
+		overdue := func(r processor.ExcelRow) int {
+			if r.SLA != nil && r.SLA.Overdue {
+				return styles.overdue
+			}
+			return 0
+		}
+		columns = append(columns,
+			excelColumn{header: "excel.header.due_date", width: 12, style: overdue,
+				value: func(r processor.ExcelRow) interface{} {
+					if r.SLA == nil {
+						return nil
+					}
+					return r.SLA.DueDate
+				}},
+			excelColumn{header: "excel.header.days_remaining", width: 12, style: overdue,
+				value: func(r processor.ExcelRow) interface{} {
+					if r.SLA == nil {
+						return nil
+					}
+					return r.SLA.DaysRemaining
+				}},
+		)
+	}
+
+	// blame 컬럼 (-blame 옵션 사용 시)
+	if data.Blame {
+		columns = append(columns,
+			excelColumn{header: "excel.header.last_commit", width: 12,
+				value: func(r processor.ExcelRow) interface{} { return shortCommitInternal(r.BlameCommit) }},
+			excelColumn{header: "excel.header.author", width: 20,
+				value: func(r processor.ExcelRow) interface{} { return r.BlameAuthor }},
+			excelColumn{header: "excel.header.commit_date", width: 20,
+				value: func(r processor.ExcelRow) interface{} { return r.BlameDate }},
+		)
+	}
+
+	// 모듈 컬럼 (모듈 안에서 검출된 finding이 있는 경우)
+	if data.Modules != nil {
+		columns = append(columns,
+			excelColumn{header: "excel.header.module",
+				value: func(r processor.ExcelRow) interface{} { return processor.ModuleName(r.Module) }},
+			excelColumn{header: "excel.header.root_file",
+				value: func(r processor.ExcelRow) interface{} { return r.RootFile }},
+		)
+	}
+
+	columns = append(columns, []excelColumn{
+		{
+			header: "excel.header.severity",
+			value:  func(r processor.ExcelRow) interface{} { return r.Severity },
+			// Severity가 CRITICAL 또는 HIGH인 경우 빨간색 텍스트 적용
+			style: func(r processor.ExcelRow) int {
+				severity := strings.ToUpper(r.Severity)
+				if severity == "CRITICAL" || severity == "HIGH" {
+					return styles.redText
+				}
+				return 0
+			},
+		},
+		{header: "excel.header.resolution", value: func(r processor.ExcelRow) interface{} { return r.Resolution }},
+		{header: "excel.header.start_line", value: func(r processor.ExcelRow) interface{} { return r.StartLine }},
+		{header: "excel.header.end_line", value: func(r processor.ExcelRow) interface{} { return r.EndLine }},
+		{header: "excel.header.primary_url", value: func(r processor.ExcelRow) interface{} { return r.PrimaryURL }},
+	}...)
+
+	// 통제 항목 참조 컬럼 (-compliance 옵션 사용 시)
+	if data.Compliance != nil {
+		columns = append(columns, excelColumn{header: "excel.header.compliance", width: 40, style: fixed(styles.wrapText),
+			value: func(r processor.ExcelRow) interface{} { return processor.FormatControls(r.Compliance) }})
+	}
+
+	// Rego 메타데이터 컬럼 (-policy-dir 옵션 사용 시)
+	if data.PolicyMetadata {
+		columns = append(columns,
+			excelColumn{header: "excel.header.owner", width: 20,
+				value: func(r processor.ExcelRow) interface{} { return r.Owner }},
+			excelColumn{header: "excel.header.risk_rationale", width: 50, style: fixed(styles.wrapText),
+				value: func(r processor.ExcelRow) interface{} { return r.RiskRationale }},
+			excelColumn{header: "excel.header.remediation_examples", width: 60, style: fixed(styles.code),
+				value: func(r processor.ExcelRow) interface{} { return r.RemediationExamples }},
+			excelColumn{header: "excel.header.related_resources", width: 50, style: fixed(styles.wrapText),
+				value: func(r processor.ExcelRow) interface{} { return r.RelatedResources }},
+		)
+	}
+
+	// 상세 컬럼 (Message, Description, Code, Occurrences)
+	if opts.Details {
+		columns = append(columns,
+			excelColumn{header: "excel.header.message", width: 50, style: fixed(styles.wrapText),
+				value: func(r processor.ExcelRow) interface{} { return r.Message }},
+			excelColumn{header: "excel.header.description", width: 50, style: fixed(styles.wrapText),
+				value: func(r processor.ExcelRow) interface{} { return r.Description }},
+			excelColumn{header: "excel.header.code", width: 80, style: fixed(styles.code),
+				value: func(r processor.ExcelRow) interface{} { return r.Code }},
+			excelColumn{header: "excel.header.occurrences", width: 60, style: fixed(styles.wrapText),
+				value: func(r processor.ExcelRow) interface{} { return r.Occurrences }},
+		)
+		if data.TagKeys != nil {
+			columns = append(columns, excelColumn{header: "excel.header.tags", width: 50, style: fixed(styles.wrapText),
+				value: func(r processor.ExcelRow) interface{} { return processor.FormatTags(r.Tags) }})
+		}
+	}
+
+	// 발견일 컬럼 (여러 스캔 입력 시)
+	if data.Trend != nil {
+		columns = append(columns,
+			excelColumn{header: "excel.header.first_seen", width: 12,
+				value: func(r processor.ExcelRow) interface{} { return r.FirstSeen }},
+			excelColumn{header: "excel.header.last_seen", width: 12,
+				value: func(r processor.ExcelRow) interface{} { return r.LastSeen }},
+		)
+	}
+
+	return columns
+}
+
+// shortCommitInternal은 커밋 ID를 앞 8자리로 줄입니다.
+func shortCommitInternal(commit string) string {
+	if len(commit) > 8 {
+		return commit[:8]
+	}
+	return commit
+}
+
+// writeExcelSheet는 특정 시트에 데이터를 작성합니다.
+func writeExcelSheet(f *excelize.File, sheetName string, rows []processor.ExcelRow, columns []excelColumn, styles *excelStyles, l i18n.Lang) error {
 	// 헤더 작성
-	headers := []string{"Target", "Title", "Resource", "Severity", "Resolution", "StartLine", "EndLine", "PrimaryURL"}
+	for i, column := range columns {
+		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
+		f.SetCellValue(sheetName, cell, l.T(column.header))
+		f.SetCellStyle(sheetName, cell, cell, styles.header)
+
+		if column.width > 0 {
+			colName, _ := excelize.ColumnNumberToName(i + 1)
+			if err := f.SetColWidth(sheetName, colName, colName, column.width); err != nil {
+				return fmt.Errorf("%s: %w", l.T("err.column_width"), err)
+			}
+		}
+	}
+
+	// 데이터 작성
+	rowNum := 2
+	for _, excelRow := range rows {
+		for i, column := range columns {
+			cell, _ := excelize.CoordinatesToCellName(i+1, rowNum)
+			f.SetCellValue(sheetName, cell, column.value(excelRow))
+
+			if column.style != nil {
+				if style := column.style(excelRow); style != 0 {
+					f.SetCellStyle(sheetName, cell, cell, style)
+				}
+			}
+		}
+		rowNum++
+	}
+
+	return nil
+}
+
+// writeTrendSheet는 스캔별 심각도/카테고리 집계 표와 꺾은선 차트를 작성합니다.
+func writeTrendSheet(f *excelize.File, sheetName string, data *processor.ExcelData, styles *excelStyles, l i18n.Lang) error {
+	trend := data.Trend
+
+	// 헤더 작성 (카테고리 컬럼은 시트 순서)
+	headers := []string{
+		l.T("excel.header.created_at"),
+		l.T("excel.header.artifact"),
+		"CRITICAL", "HIGH", "MEDIUM", "LOW",
+	}
+	for _, category := range data.Categories {
+		headers = append(headers, categorySheetNameInternal(category, l))
+	}
+	headers = append(headers, l.T("excel.header.total"))
+
 	for i, header := range headers {
 		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
 		f.SetCellValue(sheetName, cell, header)
-		f.SetCellStyle(sheetName, cell, cell, headerStyle)
+		f.SetCellStyle(sheetName, cell, cell, styles.header)
 	}
+	f.SetColWidth(sheetName, "A", "B", 28)
 
 	// 데이터 작성
 	rowNum := 2
-	for _, excelRow := range rows {
-		f.SetCellValue(sheetName, fmt.Sprintf("A%d", rowNum), excelRow.Target)
-		f.SetCellValue(sheetName, fmt.Sprintf("B%d", rowNum), excelRow.Title)
-		f.SetCellValue(sheetName, fmt.Sprintf("C%d", rowNum), excelRow.Resource)
-		f.SetCellValue(sheetName, fmt.Sprintf("D%d", rowNum), excelRow.Severity)
+	for _, point := range trend.Points {
+		values := []interface{}{
+			point.CreatedAt,
+			point.ArtifactName,
+			point.Severity.Critical,
+			point.Severity.High,
+			point.Severity.Medium,
+			point.Severity.Low,
+		}
+		for _, category := range data.Categories {
+			values = append(values, point.Categories[category.Name])
+		}
+		values = append(values, point.Total)
+
+		for i, value := range values {
+			cell, _ := excelize.CoordinatesToCellName(i+1, rowNum)
+			f.SetCellValue(sheetName, cell, value)
+		}
+		rowNum++
+	}
+
+	if len(trend.Points) == 0 {
+		return nil
+	}
+
+	// 심각도별 추이 차트 (C~F 컬럼)
+	if err := addTrendChartInternal(f, sheetName, "K2", l.T("excel.chart.severity"), columnRangeInternal(3, 6), len(trend.Points)); err != nil {
+		return err
+	}
+
+	// 카테고리별 추이 차트 (G 컬럼부터 카테고리 + 합계)
+	if err := addTrendChartInternal(f, sheetName, "K20", l.T("excel.chart.category"), columnRangeInternal(7, len(headers)), len(trend.Points)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// columnRangeInternal은 from~to 번째(1부터) 컬럼 이름 목록을 반환합니다.
+func columnRangeInternal(from, to int) []string {
+	columns := make([]string, 0, to-from+1)
+	for i := from; i <= to; i++ {
+		name, _ := excelize.ColumnNumberToName(i)
+		columns = append(columns, name)
+	}
+	return columns
+}
+
+// writeModulesSheet는 모듈 인스턴스별 심각도/카테고리 집계 표를 작성합니다.
+func writeModulesSheet(f *excelize.File, sheetName string, data *processor.ExcelData, styles *excelStyles, l i18n.Lang) error {
+	// 헤더 작성 (카테고리 컬럼은 시트 순서)
+	headers := []string{
+		l.T("excel.header.module"),
+		l.T("excel.header.root_file"),
+		l.T("excel.header.targets"),
+		"CRITICAL", "HIGH", "MEDIUM", "LOW",
+	}
+	for _, category := range data.Categories {
+		headers = append(headers, categorySheetNameInternal(category, l))
+	}
+	headers = append(headers, l.T("excel.header.total"))
+
+	for i, header := range headers {
+		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
+		f.SetCellValue(sheetName, cell, header)
+		f.SetCellStyle(sheetName, cell, cell, styles.header)
+	}
+	if err := f.SetColWidth(sheetName, "A", "C", 32); err != nil {
+		return fmt.Errorf("%s: %w", l.T("err.column_width"), err)
+	}
+
+	// 데이터 작성
+	for rowIndex, module := range data.Modules {
+		values := []interface{}{
+			module.Module,
+			module.RootFile,
+			strings.Join(module.Targets, "\n"),
+			module.Severity.Critical,
+			module.Severity.High,
+			module.Severity.Medium,
+			module.Severity.Low,
+		}
+		for _, category := range data.Categories {
+			values = append(values, module.Categories[category.Name])
+		}
+		values = append(values, module.Total)
+
+		for i, value := range values {
+			cell, _ := excelize.CoordinatesToCellName(i+1, rowIndex+2)
+			f.SetCellValue(sheetName, cell, value)
+			if i == 2 {
+				f.SetCellStyle(sheetName, cell, cell, styles.wrapText)
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeUnownedSheet는 CODEOWNERS에서 담당자를 찾지 못한 타겟별 finding 집계 표를 작성합니다.
+func writeUnownedSheet(f *excelize.File, sheetName string, report *processor.UnownedReport, styles *excelStyles, l i18n.Lang) error {
+	writeHeaderRowInternal(f, sheetName, styles, []string{
+		l.T("excel.header.target"),
+		"CRITICAL", "HIGH", "MEDIUM", "LOW",
+		l.T("excel.header.total"),
+		l.T("excel.header.policies"),
+	})
+	f.SetColWidth(sheetName, "A", "A", 40)
+	if err := f.SetColWidth(sheetName, "G", "G", 60); err != nil {
+		return fmt.Errorf("%s: %w", l.T("err.column_width"), err)
+	}
+
+	for i, target := range report.Targets {
+		writeRowInternal(f, sheetName, i+2, []interface{}{
+			target.Target,
+			target.SeveritySummary.Critical,
+			target.SeveritySummary.High,
+			target.SeveritySummary.Medium,
+			target.SeveritySummary.Low,
+			target.Total,
+			strings.Join(target.Policies, ", "),
+		})
+	}
+
+	return nil
+}
+
+// writePrioritySheet는 위험 점수 순 finding 목록과 점수 계산에 사용한 배수를 작성합니다.
+func writePrioritySheet(f *excelize.File, sheetName string, data *processor.ExcelData, styles *excelStyles, l i18n.Lang) error {
+	writeHeaderRowInternal(f, sheetName, styles, []string{
+		l.T("excel.header.rank"),
+		l.T("excel.header.risk_score"),
+		l.T("excel.header.severity"),
+		l.T("excel.header.category"),
+		l.T("excel.header.policy_id"),
+		l.T("excel.header.title"),
+		l.T("excel.header.target"),
+		l.T("excel.header.resource"),
+		l.T("excel.header.start_line"),
+		l.T("excel.header.severity_score"),
+		l.T("excel.header.category_weight"),
+		l.T("excel.header.tag_weight"),
+		l.T("excel.header.exposure"),
+		l.T("excel.header.age_days"),
+		l.T("excel.header.age_weight"),
+	})
+	f.SetColWidth(sheetName, "F", "F", 50)
+	if err := f.SetColWidth(sheetName, "G", "H", 30); err != nil {
+		return fmt.Errorf("%s: %w", l.T("err.column_width"), err)
+	}
+
+	// 카테고리는 시트 이름으로 표시
+	sheets := make(map[string]string, len(data.Categories))
+	for _, category := range data.Categories {
+		sheets[category.Name] = categorySheetNameInternal(category, l)
+	}
+
+	for i, finding := range data.Priority.Findings {
+		rowNum := i + 2
+		writeRowInternal(f, sheetName, rowNum, []interface{}{
+			finding.Rank,
+			finding.Risk.Score,
+			finding.Severity,
+			sheets[finding.Category],
+			finding.PolicyID,
+			finding.Title,
+			finding.Target,
+			finding.Resource,
+			finding.StartLine,
+			finding.Risk.Severity,
+			finding.Risk.Category,
+			finding.Risk.Tag,
+			finding.Risk.Exposure,
+			finding.Risk.AgeDays,
+			finding.Risk.Age,
+		})
 
 		// Severity가 CRITICAL 또는 HIGH인 경우 빨간색 텍스트 적용
-		severity := strings.ToUpper(excelRow.Severity)
-		if severity == "CRITICAL" || severity == "HIGH" {
-			severityCell := fmt.Sprintf("D%d", rowNum)
-			f.SetCellStyle(sheetName, severityCell, severityCell, redTextStyle)
+		if severity := strings.ToUpper(finding.Severity); severity == "CRITICAL" || severity == "HIGH" {
+			cell, _ := excelize.CoordinatesToCellName(3, rowNum)
+			f.SetCellStyle(sheetName, cell, cell, styles.redText)
 		}
+	}
 
-		f.SetCellValue(sheetName, fmt.Sprintf("E%d", rowNum), excelRow.Resolution)
-		f.SetCellValue(sheetName, fmt.Sprintf("F%d", rowNum), excelRow.StartLine)
-		f.SetCellValue(sheetName, fmt.Sprintf("G%d", rowNum), excelRow.EndLine)
-		f.SetCellValue(sheetName, fmt.Sprintf("H%d", rowNum), excelRow.PrimaryURL)
-		rowNum++
+	return nil
+}
+
+// writeOverdueSheet는 조치 기한을 초과한 finding 목록을 초과 일수가 큰 순으로 작성합니다.
+func writeOverdueSheet(f *excelize.File, sheetName string, data *processor.ExcelData, styles *excelStyles, l i18n.Lang) error {
+	writeHeaderRowInternal(f, sheetName, styles, []string{
+		l.T("excel.header.days_overdue"),
+		l.T("excel.header.due_date"),
+		l.T("excel.header.first_seen"),
+		l.T("excel.header.severity"),
+		l.T("excel.header.category"),
+		l.T("excel.header.policy_id"),
+		l.T("excel.header.title"),
+		l.T("excel.header.target"),
+		l.T("excel.header.resource"),
+		l.T("excel.header.start_line"),
+	})
+	f.SetColWidth(sheetName, "G", "G", 50)
+	if err := f.SetColWidth(sheetName, "H", "I", 30); err != nil {
+		return fmt.Errorf("%s: %w", l.T("err.column_width"), err)
+	}
+
+	sheets := make(map[string]string, len(data.Categories))
+	for _, category := range data.Categories {
+		sheets[category.Name] = categorySheetNameInternal(category, l)
+	}
+
+	for i, finding := range data.SLA.Findings {
+		rowNum := i + 2
+		writeRowInternal(f, sheetName, rowNum, []interface{}{
+			finding.DaysOverdue,
+			finding.DueDate,
+			finding.FirstSeen,
+			finding.Severity,
+			sheets[finding.Category],
+			finding.PolicyID,
+			finding.Title,
+			finding.Target,
+			finding.Resource,
+			finding.StartLine,
+		})
+		cell, _ := excelize.CoordinatesToCellName(1, rowNum)
+		f.SetCellStyle(sheetName, cell, cell, styles.overdue)
+	}
+
+	return nil
+}
+
+// writeTagsSheet는 리소스 태그 키/값별 심각도 집계 표를 작성합니다.
+func writeTagsSheet(f *excelize.File, sheetName string, summaries []processor.TagSummary, styles *excelStyles, l i18n.Lang) error {
+	writeHeaderRowInternal(f, sheetName, styles, []string{
+		l.T("excel.header.tag_key"),
+		l.T("excel.header.tag_value"),
+		"CRITICAL", "HIGH", "MEDIUM", "LOW",
+		l.T("excel.header.total"),
+	})
+	if err := f.SetColWidth(sheetName, "A", "B", 24); err != nil {
+		return fmt.Errorf("%s: %w", l.T("err.column_width"), err)
+	}
+
+	for i, summary := range summaries {
+		writeRowInternal(f, sheetName, i+2, []interface{}{
+			summary.Key,
+			summary.Value,
+			summary.Severity.Critical,
+			summary.Severity.High,
+			summary.Severity.Medium,
+			summary.Severity.Low,
+			summary.Total,
+		})
+	}
+
+	return nil
+}
+
+// writeComplianceSheets는 프레임워크별 준수 현황 요약과 통제 항목별 상태 시트를 작성합니다.
+func writeComplianceSheets(f *excelize.File, summarySheet, controlsSheet string, frameworks []processor.FrameworkSummary, styles *excelStyles, l i18n.Lang) error {
+	// 요약 시트: 프레임워크별 충족/미충족/미평가 통제 항목 수 (충족률은 평가된 통제 항목 기준)
+	writeHeaderRowInternal(f, summarySheet, styles, []string{
+		l.T("excel.header.framework"),
+		l.T("excel.header.name"),
+		l.T("excel.header.controls"),
+		l.T("excel.header.passing"),
+		l.T("excel.header.failing"),
+		l.T("excel.header.not_evaluated"),
+		l.T("excel.header.pass_rate"),
+	})
+	if err := f.SetColWidth(summarySheet, "A", "B", 32); err != nil {
+		return fmt.Errorf("%s: %w", l.T("err.column_width"), err)
+	}
+
+	for i, framework := range frameworks {
+		evaluated := framework.Passing + framework.Failing
+		passRate := 0.0
+		if evaluated > 0 {
+			passRate = float64(framework.Passing) * 100 / float64(evaluated)
+		}
+		writeRowInternal(f, summarySheet, i+2, []interface{}{
+			framework.ID,
+			framework.Name,
+			evaluated + framework.NotEvaluated,
+			framework.Passing,
+			framework.Failing,
+			framework.NotEvaluated,
+			fmt.Sprintf("%.1f", passRate),
+		})
+	}
+
+	// 통제 항목 시트: 통제 항목별 상태와 실패한 정책
+	writeHeaderRowInternal(f, controlsSheet, styles, []string{
+		l.T("excel.header.framework"),
+		l.T("excel.header.control"),
+		l.T("excel.header.title"),
+		l.T("excel.header.status"),
+		l.T("excel.header.findings"),
+		l.T("excel.header.policies"),
+	})
+	f.SetColWidth(controlsSheet, "A", "A", 16)
+	f.SetColWidth(controlsSheet, "C", "C", 60)
+	if err := f.SetColWidth(controlsSheet, "F", "F", 40); err != nil {
+		return fmt.Errorf("%s: %w", l.T("err.column_width"), err)
+	}
+
+	rowNum := 2
+	for _, framework := range frameworks {
+		for _, control := range framework.Controls {
+			writeRowInternal(f, controlsSheet, rowNum, []interface{}{
+				framework.ID,
+				control.ID,
+				control.Title,
+				control.Status,
+				control.Findings,
+				strings.Join(control.Policies, ", "),
+			})
+
+			// 미충족 통제 항목은 빨간색 텍스트 적용
+			if control.Status == processor.ControlFail {
+				cell, _ := excelize.CoordinatesToCellName(4, rowNum)
+				f.SetCellStyle(controlsSheet, cell, cell, styles.redText)
+			}
+			rowNum++
+		}
+	}
+
+	return nil
+}
+
+// writeHeaderRowInternal은 첫 행에 헤더를 작성합니다.
+func writeHeaderRowInternal(f *excelize.File, sheetName string, styles *excelStyles, headers []string) {
+	for i, header := range headers {
+		cell, _ := excelize.CoordinatesToCellName(i+1, 1)
+		f.SetCellValue(sheetName, cell, header)
+		f.SetCellStyle(sheetName, cell, cell, styles.header)
+	}
+}
+
+// writeRowInternal은 지정한 행에 값을 순서대로 작성합니다.
+func writeRowInternal(f *excelize.File, sheetName string, rowNum int, values []interface{}) {
+	for i, value := range values {
+		cell, _ := excelize.CoordinatesToCellName(i+1, rowNum)
+		f.SetCellValue(sheetName, cell, value)
+	}
+}
+
+// addTrendChartInternal은 지정한 컬럼들을 계열로 하는 꺾은선 차트를 추가합니다.
+// X축은 A 컬럼(CreatedAt)을 사용합니다.
+func addTrendChartInternal(f *excelize.File, sheetName, cell, title string, columns []string, pointCount int) error {
+	lastRow := pointCount + 1
+	series := make([]excelize.ChartSeries, 0, len(columns))
+	for _, col := range columns {
+		series = append(series, excelize.ChartSeries{
+			Name:       fmt.Sprintf("'%s'!$%s$1", sheetName, col),
+			Categories: fmt.Sprintf("'%s'!$A$2:$A$%d", sheetName, lastRow),
+			Values:     fmt.Sprintf("'%s'!$%s$2:$%s$%d", sheetName, col, col, lastRow),
+			Marker:     excelize.ChartMarker{Symbol: "circle"},
+		})
+	}
+
+	if err := f.AddChart(sheetName, cell, &excelize.Chart{
+		Type:   excelize.Line,
+		Series: series,
+		Title:  []excelize.RichTextRun{{Text: title}},
+		Legend: excelize.ChartLegend{Position: "bottom"},
+	}); err != nil {
+		return fmt.Errorf("%s: %w", i18n.T("err.chart_create"), err)
 	}
 
 	return nil
@@ -1,9 +1,16 @@
 package io
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"strings"
+	"trivy-parser/i18n"
 	"trivy-parser/processor"
 )
 
@@ -12,22 +19,282 @@ import (
 func ReadFile(path string) (*processor.TrivyResult, float64, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return nil, 0, fmt.Errorf("파일 읽기 실패: %w", err)
+		return nil, 0, fmt.Errorf("%s: %w", i18n.T("err.file_read"), err)
 	}
 
+	result, err := DecodeResult(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sizeMB := float64(len(data)) / (1024 * 1024)
+	return result, sizeMB, nil
+}
+
+// DecodeResult는 Trivy JSON 내용을 TrivyResult 구조체로 파싱합니다 (업로드 등 파일이 아닌 입력용).
+func DecodeResult(data []byte) (*processor.TrivyResult, error) {
 	var result processor.TrivyResult
 	if err := json.Unmarshal(data, &result); err != nil {
-		return nil, 0, fmt.Errorf("JSON 파싱 실패: %w", err)
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.json_parse"), err)
 	}
+	return &result, nil
+}
 
-	sizeMB := float64(len(data)) / (1024 * 1024)
-	return &result, sizeMB, nil
+// ReadNormalizedFile은 정규화된 preprocess 파일을 읽고 정책 카탈로그로 채워 원래 그룹화 형태로 복원합니다.
+// catalogPath가 비어있으면 파일에 기록된 PolicyCatalog 경로(파일 기준 상대 경로)를 사용합니다.
+func ReadNormalizedFile(path, catalogPath string) (*processor.GroupedTrivyResult, error) {
+	var normalized processor.NormalizedTrivyResult
+	if err := readJSONInternal(path, &normalized); err != nil {
+		return nil, err
+	}
+
+	if catalogPath == "" {
+		catalogPath = filepath.Join(filepath.Dir(path), filepath.FromSlash(normalized.PolicyCatalog))
+	}
+	catalog, err := ReadPolicyCatalog(catalogPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return processor.RehydrateResult(&normalized, catalog)
+}
+
+// ReadPolicyCatalog는 정책 카탈로그 파일을 읽습니다.
+func ReadPolicyCatalog(path string) (processor.PolicyCatalog, error) {
+	var catalog processor.PolicyCatalog
+	if err := readJSONInternal(path, &catalog); err != nil {
+		return nil, err
+	}
+	return catalog, nil
+}
+
+// ReadClassifier는 정책 분류 설정 파일(JSON)을 읽어 분류기를 생성합니다.
+func ReadClassifier(path string) (*processor.Classifier, error) {
+	var config processor.ClassifierConfig
+	if err := readJSONInternal(path, &config); err != nil {
+		return nil, err
+	}
+	return processor.NewClassifier(config)
+}
+
+// ReadRiskConfig는 위험 점수 설정 파일(JSON)을 읽어 위험 점수 모델을 생성합니다.
+func ReadRiskConfig(path string, tagWeights []processor.TagWeight) (*processor.RiskModel, error) {
+	var config processor.RiskConfig
+	if err := readJSONInternal(path, &config); err != nil {
+		return nil, err
+	}
+	return processor.NewRiskModel(config, tagWeights)
+}
+
+// ReadSLAConfig는 조치 기한 설정 파일(JSON)을 읽어 SLA 정책을 생성합니다.
+func ReadSLAConfig(path string) (*processor.SLAPolicy, error) {
+	var config processor.SLAConfig
+	if err := readJSONInternal(path, &config); err != nil {
+		return nil, err
+	}
+	return processor.NewSLAPolicy(config)
+}
+
+// ReadComplianceMappings는 컴플라이언스 매핑 목록을 읽어 하나로 합칩니다.
+// "builtin"은 내장 AWS 매핑, 그 외는 매핑 파일(JSON) 경로이며 뒤에 나온 매핑이 앞의 매핑에 합쳐집니다.
+func ReadComplianceMappings(specs []string) (*processor.ComplianceMapping, error) {
+	mapping := &processor.ComplianceMapping{}
+	for _, spec := range specs {
+		var next *processor.ComplianceMapping
+		if spec == processor.BuiltinComplianceName {
+			builtin, err := processor.BuiltinComplianceMapping()
+			if err != nil {
+				return nil, err
+			}
+			next = builtin
+		} else {
+			next = &processor.ComplianceMapping{}
+			if err := readJSONInternal(spec, next); err != nil {
+				return nil, fmt.Errorf("%s: %w", spec, err)
+			}
+		}
+		mapping.Merge(next)
+	}
+
+	if err := mapping.Validate(); err != nil {
+		return nil, err
+	}
+	return mapping, nil
+}
+
+// ReadPolicyDir는 디렉터리 아래의 Rego 정책 파일(*.rego, 테스트 파일 제외)을 찾아 METADATA 주석을 namespace별로 읽습니다.
+func ReadPolicyDir(dir string) (processor.PolicyMetadataIndex, error) {
+	index := make(processor.PolicyMetadataIndex)
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || filepath.Ext(path) != ".rego" || strings.HasSuffix(path, "_test.rego") {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		metadata, err := processor.ParseRegoMetadata(filepath.ToSlash(path), content)
+		if err != nil {
+			return err
+		}
+		if metadata != nil {
+			index.Add(metadata)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.file_read"), err)
+	}
+	return index, nil
+}
+
+// ReadCodeOwners는 CODEOWNERS 파일을 읽습니다.
+func ReadCodeOwners(path string) (*processor.CodeOwners, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.file_read"), err)
+	}
+	return processor.ParseCodeOwners(data)
+}
+
+// ReadTerraformDir는 루트 아래의 .tf 파일을 모두 읽어 리소스 인덱스를 만듭니다.
+// .terraform 등 '.'으로 시작하는 디렉터리는 건너뜁니다. 파일 경로는 루트 기준 상대 경로입니다.
+func ReadTerraformDir(root string) (*processor.TerraformIndex, error) {
+	files := make(map[string][]byte)
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != root && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".tf" {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = content
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.file_read"), err)
+	}
+	return processor.ParseTerraformFiles(files), nil
+}
+
+// ReadSources는 스캔한 저장소 루트(root)에서 타겟 파일을 읽습니다.
+// 루트 밖을 가리키거나 로컬에 없는 타겟은 결과에서 제외합니다.
+func ReadSources(root string, targets []string) (processor.SourceIndex, error) {
+	sources := make(processor.SourceIndex, len(targets))
+	for _, target := range targets {
+		rel := filepath.Clean(filepath.FromSlash(target))
+		if filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(root, rel))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", i18n.T("err.file_read"), err)
+		}
+		sources[target] = processor.NewSourceFile(content)
+	}
+	return sources, nil
+}
+
+// ReadSourceHashes는 스캔 시점의 파일 해시 목록을 읽습니다.
+// sha256sum 출력 형식("<sha256>  <경로>")이며, 경로는 저장소 루트 기준 상대 경로입니다.
+func ReadSourceHashes(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.file_read"), err)
+	}
+
+	hashes := make(map[string]string)
+	for n, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		sum, file, found := strings.Cut(line, " ")
+		if !found || len(sum) != 64 {
+			return nil, fmt.Errorf("%s:%d: invalid sha256sum line: %q", path, n+1, line)
+		}
+		// 바이너리 모드 표시("*")와 "./" 접두사 제거
+		file = strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(file), "*"), "./")
+		hashes[filepath.ToSlash(file)] = strings.ToLower(sum)
+	}
+	return hashes, nil
+}
+
+// readJSONInternal은 JSON 파일을 읽어 v에 파싱합니다.
+func readJSONInternal(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", i18n.T("err.file_read"), err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%s: %w", i18n.T("err.json_parse"), err)
+	}
+	return nil
+}
+
+// ReadFiles는 여러 JSON 파일을 읽어 TrivyResult 목록으로 파싱합니다.
+// 전체 파일 크기(MB)의 합도 함께 반환합니다.
+func ReadFiles(paths []string) ([]*processor.TrivyResult, float64, error) {
+	results := make([]*processor.TrivyResult, 0, len(paths))
+	var totalSize float64
+
+	for _, path := range paths {
+		result, sizeMB, err := ReadFile(path)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%s: %w", path, err)
+		}
+		results = append(results, result)
+		totalSize += sizeMB
+	}
+
+	return results, totalSize, nil
+}
+
+// WrittenFile은 저장된 파일의 크기와 체크섬 정보를 담습니다.
+type WrittenFile struct {
+	Size   int64  // 바이트 단위 크기
+	SHA256 string // 내용의 SHA-256 (16진수)
 }
 
-// WriteFile은 데이터를 JSON 형식으로 파일에 저장합니다.
-// pretty가 true이면 들여쓰기를 포함합니다.
-// 저장된 파일 크기(MB)를 반환합니다.
-func WriteFile(path string, data interface{}, pretty bool) (float64, error) {
+// WriteJSON은 데이터를 JSON 형식으로 파일에 저장하고 크기와 체크섬을 반환합니다.
+func WriteJSON(path string, data interface{}, pretty bool) (*WrittenFile, error) {
+	output, err := marshalJSONInternal(data, pretty)
+	if err != nil {
+		return nil, err
+	}
+	return writeBytesInternal(path, output)
+}
+
+// WriteText는 텍스트를 파일에 저장하고 크기와 체크섬을 반환합니다.
+func WriteText(path string, text string) (*WrittenFile, error) {
+	return writeBytesInternal(path, []byte(text))
+}
+
+// marshalJSONInternal은 데이터를 JSON으로 변환합니다 (pretty이면 들여쓰기 포함).
+func marshalJSONInternal(data interface{}, pretty bool) ([]byte, error) {
 	var output []byte
 	var err error
 
@@ -38,13 +305,22 @@ func WriteFile(path string, data interface{}, pretty bool) (float64, error) {
 	}
 
 	if err != nil {
-		return 0, fmt.Errorf("JSON 생성 실패: %w", err)
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.json_marshal"), err)
 	}
+	return output, nil
+}
 
+func writeBytesInternal(path string, output []byte) (*WrittenFile, error) {
 	if err := os.WriteFile(path, output, 0644); err != nil {
-		return 0, fmt.Errorf("파일 저장 실패: %w", err)
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.file_write"), err)
 	}
+	return newWrittenFileInternal(output), nil
+}
 
-	sizeMB := float64(len(output)) / (1024 * 1024)
-	return sizeMB, nil
-}
\ No newline at end of file
+func newWrittenFileInternal(output []byte) *WrittenFile {
+	sum := sha256.Sum256(output)
+	return &WrittenFile{
+		Size:   int64(len(output)),
+		SHA256: hex.EncodeToString(sum[:]),
+	}
+}
@@ -0,0 +1,55 @@
+package io
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"trivy-parser/processor"
+)
+
+func TestReadNormalizedFileRoundTrip(t *testing.T) {
+	input, _, err := ReadFile("../test-input/result-01.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetMap := processor.Preprocess(input, nil)
+
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "nested"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := WriteJSON(filepath.Join(dir, processor.PolicyCatalogFilename), processor.BuildPolicyCatalog(targetMap), false); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range processor.SortedTargetKeys(targetMap) {
+		// 하위 디렉토리의 파일도 기록된 카탈로그 상대 경로로 복원되는지 확인
+		path := filepath.Join(dir, "nested", key+".json")
+		normalized := processor.NormalizeResult(targetMap[key], "../"+processor.PolicyCatalogFilename)
+		if _, err := WriteJSON(path, normalized, false); err != nil {
+			t.Fatal(err)
+		}
+
+		rehydrated, err := ReadNormalizedFile(path, "")
+		if err != nil {
+			t.Fatalf("%s: %v", key, err)
+		}
+		got, _ := json.Marshal(rehydrated)
+		want, _ := json.Marshal(targetMap[key])
+		if string(got) != string(want) {
+			t.Errorf("%s: rehydrated result differs from original\ngot:  %s\nwant: %s", key, got, want)
+		}
+	}
+}
+
+func TestReadNormalizedFileMissingCatalog(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "builtin-main.tf.json")
+	if _, err := WriteJSON(path, &processor.NormalizedTrivyResult{PolicyCatalog: processor.PolicyCatalogFilename}, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadNormalizedFile(path, ""); err == nil {
+		t.Fatal("expected error for missing policy catalog")
+	}
+}
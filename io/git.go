@@ -0,0 +1,56 @@
+package io
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"trivy-parser/processor"
+)
+
+// GitBlamer는 로컬 git 저장소에서 `git blame`을 실행하여 파일별 blame 결과를 제공합니다.
+// 원격 저장소에 접근하지 않으며, 같은 파일은 한 번만 실행하도록 결과(실패 포함)를 캐시합니다.
+type GitBlamer struct {
+	root  string
+	cache map[string]blameResultInternal
+}
+
+type blameResultInternal struct {
+	lines []processor.BlameLine
+	err   error
+}
+
+// NewGitBlamer는 저장소 루트를 확인하고 GitBlamer를 생성합니다.
+func NewGitBlamer(root string) (*GitBlamer, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, fmt.Errorf("git not found: %w", err)
+	}
+	cmd := exec.Command("git", "-C", root, "rev-parse", "--git-dir")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("%s is not a git repository: %s", root, strings.TrimSpace(string(output)))
+	}
+	return &GitBlamer{root: root, cache: make(map[string]blameResultInternal)}, nil
+}
+
+// BlameFile은 저장소 루트 기준 경로의 blame 결과를 반환합니다.
+func (g *GitBlamer) BlameFile(target string) ([]processor.BlameLine, error) {
+	if cached, exists := g.cache[target]; exists {
+		return cached.lines, cached.err
+	}
+
+	lines, err := g.blameInternal(target)
+	g.cache[target] = blameResultInternal{lines: lines, err: err}
+	return lines, err
+}
+
+func (g *GitBlamer) blameInternal(target string) ([]processor.BlameLine, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", "-C", g.root, "blame", "--porcelain", "--", filepath.ToSlash(target))
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("git blame %s: %s", target, strings.TrimSpace(stderr.String()))
+	}
+	return processor.ParseBlamePorcelain(stdout.Bytes())
+}
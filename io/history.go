@@ -0,0 +1,213 @@
+package io
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"trivy-parser/i18n"
+	"trivy-parser/processor"
+
+	_ "modernc.org/sqlite" // 순수 Go SQLite 드라이버 (cgo 불필요)
+)
+
+// historySchema는 이력 저장소 스키마입니다.
+// 같은 아티팩트의 같은 CreatedAt 스캔은 한 번만 저장합니다.
+const historySchema = `
+CREATE TABLE IF NOT EXISTS scans (
+	id            INTEGER PRIMARY KEY AUTOINCREMENT,
+	artifact_name TEXT NOT NULL,
+	artifact_type TEXT NOT NULL,
+	created_at    TEXT NOT NULL,
+	source        TEXT NOT NULL,
+	ingested_at   TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now')),
+	UNIQUE (artifact_name, created_at)
+);
+CREATE TABLE IF NOT EXISTS findings (
+	scan_id     INTEGER NOT NULL REFERENCES scans(id) ON DELETE CASCADE,
+	fingerprint TEXT NOT NULL,
+	category    TEXT NOT NULL,
+	target      TEXT NOT NULL,
+	policy_id   TEXT NOT NULL,
+	avd_id      TEXT NOT NULL,
+	namespace   TEXT NOT NULL,
+	severity    TEXT NOT NULL,
+	title       TEXT NOT NULL,
+	resource    TEXT NOT NULL,
+	start_line  INTEGER NOT NULL,
+	end_line    INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS findings_scan ON findings (scan_id);
+CREATE INDEX IF NOT EXISTS findings_fingerprint ON findings (fingerprint);
+`
+
+// HistoryStore는 스캔 결과 이력을 저장하는 로컬 SQLite 데이터베이스입니다.
+type HistoryStore struct {
+	db *sql.DB
+}
+
+// historyTables는 이력 데이터베이스에 있어야 하는 테이블입니다.
+var historyTables = []string{"scans", "findings"}
+
+// OpenHistoryStore는 이력 데이터베이스를 열고 스키마를 준비합니다.
+// create가 false이면 (조회용) 스키마를 만들지 않으며, 파일이나 테이블이 없으면 에러를 반환합니다.
+func OpenHistoryStore(path string, create bool) (*HistoryStore, error) {
+	mode := "rwc"
+	if !create {
+		if _, err := os.Stat(path); err != nil {
+			return nil, fmt.Errorf("%s: %w", i18n.T("err.history_open"), err)
+		}
+		mode = "rw"
+	}
+	db, err := sql.Open("sqlite", "file:"+path+"?mode="+mode+"&_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.history_open"), err)
+	}
+	if create {
+		_, err = db.Exec(historySchema)
+	} else {
+		err = checkHistoryTablesInternal(db)
+	}
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.history_open"), err)
+	}
+	return &HistoryStore{db: db}, nil
+}
+
+// checkHistoryTablesInternal은 이력 테이블이 모두 있는지 확인합니다.
+func checkHistoryTablesInternal(db *sql.DB) error {
+	for _, table := range historyTables {
+		var name string
+		err := db.QueryRow(`SELECT name FROM sqlite_master WHERE type = 'table' AND name = ?`, table).Scan(&name)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("missing table %q (not a history database?)", table)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close는 데이터베이스를 닫습니다.
+func (s *HistoryStore) Close() error {
+	return s.db.Close()
+}
+
+// Ingest는 스캔 결과 1개를 저장합니다. 이미 저장된 스캔(같은 ArtifactName, CreatedAt)이면 false를 반환합니다.
+// source는 원본 파일 경로이며, 정책 카테고리는 classifier(nil이면 DefaultClassifier)로 분류합니다.
+func (s *HistoryStore) Ingest(scan *processor.TrivyResult, source string, classifier *processor.Classifier) (bool, int, error) {
+	if classifier == nil {
+		classifier = processor.DefaultClassifier()
+	}
+	if _, err := processor.ParseCreatedAt(scan.CreatedAt); err != nil {
+		return false, 0, fmt.Errorf("%s: invalid CreatedAt %q", i18n.T("err.history_ingest"), scan.CreatedAt)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return false, 0, fmt.Errorf("%s: %w", i18n.T("err.history_ingest"), err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`INSERT INTO scans (artifact_name, artifact_type, created_at, source) VALUES (?, ?, ?, ?)
+		ON CONFLICT (artifact_name, created_at) DO NOTHING`,
+		scan.ArtifactName, scan.ArtifactType, scan.CreatedAt, source)
+	if err != nil {
+		return false, 0, fmt.Errorf("%s: %w", i18n.T("err.history_ingest"), err)
+	}
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return false, 0, nil
+	}
+	scanID, err := res.LastInsertId()
+	if err != nil {
+		return false, 0, fmt.Errorf("%s: %w", i18n.T("err.history_ingest"), err)
+	}
+
+	stmt, err := tx.Prepare(`INSERT INTO findings
+		(scan_id, fingerprint, category, target, policy_id, avd_id, namespace, severity, title, resource, start_line, end_line)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return false, 0, fmt.Errorf("%s: %w", i18n.T("err.history_ingest"), err)
+	}
+	defer stmt.Close()
+
+	count := 0
+	for _, result := range scan.Results {
+		for _, misconfig := range result.Misconfigurations {
+			cause := misconfig.CauseMetadata
+			if _, err := stmt.Exec(scanID,
+				processor.Fingerprint(result.Target, misconfig.ID, cause.Resource),
+				classifier.Classify(misconfig.Namespace, misconfig.ID),
+				result.Target, misconfig.ID, misconfig.AVDID, misconfig.Namespace,
+				misconfig.Severity, misconfig.Title, cause.Resource, cause.StartLine, cause.EndLine,
+			); err != nil {
+				return false, 0, fmt.Errorf("%s: %w", i18n.T("err.history_ingest"), err)
+			}
+			count++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, 0, fmt.Errorf("%s: %w", i18n.T("err.history_ingest"), err)
+	}
+	return true, count, nil
+}
+
+// Load는 아티팩트의 스캔과 관측 결과를 읽습니다 (artifact가 비어 있으면 전체).
+func (s *HistoryStore) Load(artifact string) ([]processor.HistoryScan, []processor.HistoryObservation, error) {
+	scanRows, err := s.db.Query(`SELECT id, artifact_name, artifact_type, created_at FROM scans
+		WHERE ? = '' OR artifact_name = ? ORDER BY id`, artifact, artifact)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", i18n.T("err.history_query"), err)
+	}
+	defer scanRows.Close()
+
+	var scans []processor.HistoryScan
+	for scanRows.Next() {
+		var scan processor.HistoryScan
+		if err := scanRows.Scan(&scan.ID, &scan.ArtifactName, &scan.ArtifactType, &scan.CreatedAt); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", i18n.T("err.history_query"), err)
+		}
+		scans = append(scans, scan)
+	}
+	if err := scanRows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", i18n.T("err.history_query"), err)
+	}
+
+	rows, err := s.db.Query(`SELECT f.scan_id, f.fingerprint, f.category, f.target, f.policy_id, f.severity, f.title, f.resource
+		FROM findings f JOIN scans s ON s.id = f.scan_id
+		WHERE ? = '' OR s.artifact_name = ? ORDER BY f.rowid`, artifact, artifact)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", i18n.T("err.history_query"), err)
+	}
+	defer rows.Close()
+
+	var observations []processor.HistoryObservation
+	for rows.Next() {
+		var o processor.HistoryObservation
+		if err := rows.Scan(&o.ScanID, &o.Fingerprint, &o.Category, &o.Target, &o.PolicyID, &o.Severity, &o.Title, &o.Resource); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", i18n.T("err.history_query"), err)
+		}
+		observations = append(observations, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", i18n.T("err.history_query"), err)
+	}
+	return scans, observations, nil
+}
+
+// ReadHistoryDB는 이력 데이터베이스에서 아티팩트의 finding별 최초 발견 시각을 읽습니다 (-history-db).
+func ReadHistoryDB(path, artifact string) (processor.FindingHistory, error) {
+	store, err := OpenHistoryStore(path, false)
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+
+	scans, observations, err := store.Load(artifact)
+	if err != nil {
+		return nil, err
+	}
+	return processor.LifecycleHistory(processor.BuildLifecycles(scans, observations)), nil
+}
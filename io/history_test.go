@@ -0,0 +1,127 @@
+package io
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"trivy-parser/processor"
+)
+
+// historyScanInternal은 finding 2개를 가진 스캔입니다.
+func historyScanInternal(artifact, createdAt string) *processor.TrivyResult {
+	return &processor.TrivyResult{
+		ArtifactName: artifact,
+		ArtifactType: "filesystem",
+		CreatedAt:    createdAt,
+		Results: []processor.Result{{
+			Target: "main.tf",
+			Misconfigurations: []processor.Misconfiguration{
+				{ID: "AVD-AWS-0086", Namespace: "builtin.aws.s3", Severity: "HIGH", CauseMetadata: processor.CauseMetadata{Resource: "aws_s3_bucket.a", StartLine: 1, EndLine: 5}},
+				{ID: "USR-0001", Namespace: "user.security", Severity: "LOW", CauseMetadata: processor.CauseMetadata{Resource: "aws_s3_bucket.b", StartLine: 7, EndLine: 9}},
+			},
+		}},
+	}
+}
+
+func TestHistoryStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.db")
+
+	// 조회용으로 열면 없는 파일을 만들지 않음
+	if _, err := OpenHistoryStore(path, false); err == nil {
+		t.Fatal("expected error opening missing database for reading")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("read-only open created %s", path)
+	}
+
+	store, err := OpenHistoryStore(path, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ingests := []struct {
+		scan     *processor.TrivyResult
+		inserted bool
+		count    int
+	}{
+		{scan: historyScanInternal("app", "2026-01-01T00:00:00Z"), inserted: true, count: 2},
+		{scan: historyScanInternal("app", "2026-01-01T00:00:00Z"), inserted: false, count: 0}, // 같은 스캔 중복 저장
+		{scan: historyScanInternal("app", "2026-01-10T00:00:00Z"), inserted: true, count: 2},
+		{scan: historyScanInternal("other", "2026-01-01T00:00:00Z"), inserted: true, count: 2},
+	}
+	for i, ingest := range ingests {
+		inserted, count, err := store.Ingest(ingest.scan, "scan.json", nil)
+		if err != nil {
+			t.Fatalf("ingest %d: %v", i, err)
+		}
+		if inserted != ingest.inserted || count != ingest.count {
+			t.Errorf("ingest %d = (%v, %d), want (%v, %d)", i, inserted, count, ingest.inserted, ingest.count)
+		}
+	}
+	if _, _, err := store.Ingest(historyScanInternal("app", "yesterday"), "scan.json", nil); err == nil {
+		t.Error("expected error for invalid CreatedAt")
+	}
+	if err := store.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 기존 데이터베이스는 조회용으로 열 수 있음
+	store, err = OpenHistoryStore(path, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	scans, observations, err := store.Load("app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scans) != 2 || len(observations) != 4 {
+		t.Fatalf("Load(app) = %d scans, %d observations, want 2, 4", len(scans), len(observations))
+	}
+	for _, scan := range scans {
+		if scan.ArtifactName != "app" {
+			t.Errorf("Load(app) returned scan of %q", scan.ArtifactName)
+		}
+	}
+	if observations[0].Category != processor.CategoryBuiltin || observations[1].Category != processor.CategoryCustom {
+		t.Errorf("categories = %q, %q", observations[0].Category, observations[1].Category)
+	}
+	if want := processor.Fingerprint("main.tf", "AVD-AWS-0086", "aws_s3_bucket.a"); observations[0].Fingerprint != want {
+		t.Errorf("fingerprint = %q, want %q", observations[0].Fingerprint, want)
+	}
+
+	if scans, _, err := store.Load(""); err != nil || len(scans) != 3 {
+		t.Errorf("Load(\"\") = %d scans, %v, want 3", len(scans), err)
+	}
+}
+
+func TestOpenHistoryStoreRequiresSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.db")
+	db, err := sql.Open("sqlite", "file:"+path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`CREATE TABLE unrelated (id INTEGER)`); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	if _, err := OpenHistoryStore(path, false); err == nil {
+		t.Fatal("expected error for database without history tables")
+	}
+
+	// 조회용으로 연 뒤에도 스키마가 생기지 않아야 함
+	db, err = sql.Open("sqlite", "file:"+path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	var count int
+	if err := db.QueryRow(`SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = 'scans'`).Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Error("read-only open created the scans table")
+	}
+}
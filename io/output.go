@@ -0,0 +1,206 @@
+package io
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	stdio "io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"trivy-parser/i18n"
+)
+
+// OutputWriter는 preprocess 결과 파일을 저장하는 대상입니다 (출력 디렉토리 또는 압축 파일).
+// name은 출력 루트 기준 상대 경로입니다.
+type OutputWriter interface {
+	WriteJSON(name string, data interface{}, pretty bool) (*WrittenFile, error)
+	WriteText(name string, text string) (*WrittenFile, error)
+	Close() error
+}
+
+// BundleFormat은 preprocess 결과를 하나로 묶는 압축 파일 형식입니다.
+type BundleFormat string
+
+const (
+	// BundleNone은 출력 디렉토리에 파일을 그대로 저장합니다.
+	BundleNone BundleFormat = ""
+	// BundleZip은 .zip 압축 파일로 저장합니다.
+	BundleZip BundleFormat = "zip"
+	// BundleTarGz는 .tar.gz 압축 파일로 저장합니다.
+	BundleTarGz BundleFormat = "tar.gz"
+)
+
+// ParseBundleFormat은 문자열을 BundleFormat으로 변환합니다 ("tgz"는 tar.gz로 해석).
+func ParseBundleFormat(s string) (BundleFormat, error) {
+	switch format := BundleFormat(strings.ToLower(s)); format {
+	case BundleNone, BundleZip, BundleTarGz:
+		return format, nil
+	case "tgz":
+		return BundleTarGz, nil
+	}
+	return "", fmt.Errorf("unknown bundle format: %q (supported: zip, tar.gz)", s)
+}
+
+// NewBundleWriter는 w에 format 형식의 압축 파일을 기록하는 OutputWriter를 생성합니다.
+func NewBundleWriter(w stdio.Writer, format BundleFormat, modified time.Time) (OutputWriter, error) {
+	switch format {
+	case BundleZip:
+		return NewZipWriter(w, modified), nil
+	case BundleTarGz:
+		return NewTarGzWriter(w, modified), nil
+	}
+	return nil, fmt.Errorf("unknown bundle format: %q (supported: zip, tar.gz)", format)
+}
+
+// DirWriter는 출력 디렉토리에 파일을 저장합니다 (하위 디렉토리는 자동 생성).
+type DirWriter struct {
+	root string
+}
+
+// NewDirWriter는 출력 디렉토리를 만들고 DirWriter를 생성합니다.
+func NewDirWriter(root string) (*DirWriter, error) {
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, err
+	}
+	return &DirWriter{root: root}, nil
+}
+
+// WriteJSON은 데이터를 JSON 파일로 저장합니다.
+func (d *DirWriter) WriteJSON(name string, data interface{}, pretty bool) (*WrittenFile, error) {
+	path, err := d.pathInternal(name)
+	if err != nil {
+		return nil, err
+	}
+	return WriteJSON(path, data, pretty)
+}
+
+// WriteText는 텍스트 파일을 저장합니다.
+func (d *DirWriter) WriteText(name string, text string) (*WrittenFile, error) {
+	path, err := d.pathInternal(name)
+	if err != nil {
+		return nil, err
+	}
+	return WriteText(path, text)
+}
+
+// Close는 아무 작업도 하지 않습니다.
+func (d *DirWriter) Close() error {
+	return nil
+}
+
+func (d *DirWriter) pathInternal(name string) (string, error) {
+	path := filepath.Join(d.root, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// ZipWriter는 파일을 디스크에 쓰지 않고 zip 항목으로 바로 기록합니다.
+type ZipWriter struct {
+	zw       *zip.Writer
+	modified time.Time
+}
+
+// NewZipWriter는 w에 zip 압축 파일을 기록하는 ZipWriter를 생성합니다.
+// 모든 항목의 수정 시각은 modified를 사용합니다.
+func NewZipWriter(w stdio.Writer, modified time.Time) *ZipWriter {
+	return &ZipWriter{zw: zip.NewWriter(w), modified: modified}
+}
+
+// WriteJSON은 데이터를 JSON 항목으로 기록합니다.
+func (z *ZipWriter) WriteJSON(name string, data interface{}, pretty bool) (*WrittenFile, error) {
+	output, err := marshalJSONInternal(data, pretty)
+	if err != nil {
+		return nil, err
+	}
+	return z.writeInternal(name, output)
+}
+
+// WriteText는 텍스트 항목을 기록합니다.
+func (z *ZipWriter) WriteText(name string, text string) (*WrittenFile, error) {
+	return z.writeInternal(name, []byte(text))
+}
+
+// Close는 zip 중앙 디렉토리를 기록합니다 (하위 Writer는 닫지 않음).
+func (z *ZipWriter) Close() error {
+	if err := z.zw.Close(); err != nil {
+		return fmt.Errorf("%s: %w", i18n.T("err.file_write"), err)
+	}
+	return nil
+}
+
+func (z *ZipWriter) writeInternal(name string, output []byte) (*WrittenFile, error) {
+	entry, err := z.zw.CreateHeader(&zip.FileHeader{
+		Name:     filepath.ToSlash(name),
+		Method:   zip.Deflate,
+		Modified: z.modified,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.file_write"), err)
+	}
+	if _, err := entry.Write(output); err != nil {
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.file_write"), err)
+	}
+	return newWrittenFileInternal(output), nil
+}
+
+// TarGzWriter는 파일을 디스크에 쓰지 않고 tar.gz 항목으로 바로 기록합니다.
+type TarGzWriter struct {
+	gw       *gzip.Writer
+	tw       *tar.Writer
+	modified time.Time
+}
+
+// NewTarGzWriter는 w에 tar.gz 압축 파일을 기록하는 TarGzWriter를 생성합니다.
+// 모든 항목의 수정 시각은 modified를 사용합니다.
+func NewTarGzWriter(w stdio.Writer, modified time.Time) *TarGzWriter {
+	gw := gzip.NewWriter(w)
+	gw.ModTime = modified
+	return &TarGzWriter{gw: gw, tw: tar.NewWriter(gw), modified: modified}
+}
+
+// WriteJSON은 데이터를 JSON 항목으로 기록합니다.
+func (t *TarGzWriter) WriteJSON(name string, data interface{}, pretty bool) (*WrittenFile, error) {
+	output, err := marshalJSONInternal(data, pretty)
+	if err != nil {
+		return nil, err
+	}
+	return t.writeInternal(name, output)
+}
+
+// WriteText는 텍스트 항목을 기록합니다.
+func (t *TarGzWriter) WriteText(name string, text string) (*WrittenFile, error) {
+	return t.writeInternal(name, []byte(text))
+}
+
+// Close는 tar 종료 블록과 gzip 트레일러를 기록합니다 (하위 Writer는 닫지 않음).
+func (t *TarGzWriter) Close() error {
+	if err := t.tw.Close(); err != nil {
+		return fmt.Errorf("%s: %w", i18n.T("err.file_write"), err)
+	}
+	if err := t.gw.Close(); err != nil {
+		return fmt.Errorf("%s: %w", i18n.T("err.file_write"), err)
+	}
+	return nil
+}
+
+func (t *TarGzWriter) writeInternal(name string, output []byte) (*WrittenFile, error) {
+	err := t.tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     filepath.ToSlash(name),
+		Mode:     0644,
+		Size:     int64(len(output)),
+		ModTime:  t.modified,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.file_write"), err)
+	}
+	if _, err := t.tw.Write(output); err != nil {
+		return nil, fmt.Errorf("%s: %w", i18n.T("err.file_write"), err)
+	}
+	return newWrittenFileInternal(output), nil
+}
@@ -0,0 +1,81 @@
+package io
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	stdio "io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"trivy-parser/i18n"
+)
+
+// maxRetryAfter는 Retry-After 헤더로 기다리는 최대 시간입니다.
+const maxRetryAfter = time.Minute
+
+// RetryPolicy는 웹후크 전송 재시도 정책입니다.
+type RetryPolicy struct {
+	Attempts int           // 최대 시도 횟수 (1이면 재시도 없음)
+	Backoff  time.Duration // 첫 재시도 전 대기 시간 (이후 2배씩 증가)
+}
+
+// webhookClient는 웹후크 전송용 HTTP 클라이언트입니다.
+var webhookClient = &http.Client{Timeout: 30 * time.Second}
+
+// PostWebhook은 JSON 페이로드를 웹후크 URL로 전송합니다.
+// 네트워크 오류, 408, 429, 5xx 응답은 지수 백오프로 재시도하며 Retry-After 헤더가 있으면 따릅니다.
+// 웹후크 URL에는 토큰이 포함되므로 에러 메시지에 URL을 넣지 않습니다.
+func PostWebhook(webhook string, payload []byte, retry RetryPolicy) error {
+	attempts := max(retry.Attempts, 1)
+	wait := retry.Backoff
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		retryAfter, err := postWebhookInternal(webhook, payload)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if retryAfter < 0 || attempt == attempts {
+			break
+		}
+		time.Sleep(max(wait, retryAfter))
+		wait *= 2
+	}
+	return fmt.Errorf("%s: %w", i18n.T("err.notify_send"), lastErr)
+}
+
+// postWebhookInternal은 페이로드를 1회 전송합니다.
+// 재시도할 수 없는 실패이면 retryAfter로 -1을, 재시도할 수 있으면 Retry-After 대기 시간(없으면 0)을 반환합니다.
+func postWebhookInternal(webhook string, payload []byte) (time.Duration, error) {
+	resp, err := webhookClient.Post(webhook, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return 0, err
+	}
+	defer resp.Body.Close()
+	body, _ := stdio.ReadAll(stdio.LimitReader(resp.Body, 512))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return 0, nil
+	}
+	err = fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	if resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		return retryAfterInternal(resp.Header.Get("Retry-After")), err
+	}
+	return -1, err
+}
+
+// retryAfterInternal은 Retry-After 헤더(초 단위)를 대기 시간으로 변환합니다 (최대 maxRetryAfter).
+func retryAfterInternal(value string) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return min(time.Duration(seconds)*time.Second, maxRetryAfter)
+}
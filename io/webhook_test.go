@@ -0,0 +1,99 @@
+package io
+
+import (
+	stdio "io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPostWebhook(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int // 요청 순서별 응답 코드 (마지막 값 반복)
+		attempts int
+		wantHits int32
+		wantErr  string
+	}{
+		{name: "success", statuses: []int{http.StatusOK}, attempts: 3, wantHits: 1},
+		{name: "retry on 503", statuses: []int{http.StatusServiceUnavailable, http.StatusOK}, attempts: 3, wantHits: 2},
+		{name: "retry on 429", statuses: []int{http.StatusTooManyRequests, http.StatusNoContent}, attempts: 2, wantHits: 2},
+		{name: "no retry on 400", statuses: []int{http.StatusBadRequest, http.StatusOK}, attempts: 3, wantHits: 1, wantErr: "HTTP 400: rejected"},
+		{name: "attempts exhausted", statuses: []int{http.StatusBadGateway}, attempts: 3, wantHits: 3, wantErr: "HTTP 502: rejected"},
+		{name: "zero attempts sends once", statuses: []int{http.StatusInternalServerError}, attempts: 0, wantHits: 1, wantErr: "HTTP 500"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(hits.Add(1))
+				body, _ := stdio.ReadAll(r.Body)
+				if string(body) != `{"text":"hi"}` || r.Header.Get("Content-Type") != "application/json" {
+					t.Errorf("request = %s %q", r.Header.Get("Content-Type"), body)
+				}
+				status := tt.statuses[min(n, len(tt.statuses))-1]
+				w.WriteHeader(status)
+				if status >= 300 {
+					stdio.WriteString(w, "rejected\n")
+				}
+			}))
+			defer server.Close()
+
+			webhook := server.URL + "/hooks/secret-token"
+			err := PostWebhook(webhook, []byte(`{"text":"hi"}`), RetryPolicy{Attempts: tt.attempts})
+			if got := hits.Load(); got != tt.wantHits {
+				t.Errorf("hits = %d, want %d", got, tt.wantHits)
+			}
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+			if strings.Contains(err.Error(), "secret-token") {
+				t.Errorf("error leaks webhook URL: %v", err)
+			}
+		})
+	}
+}
+
+func TestPostWebhookNetworkError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	webhook := server.URL + "/hooks/secret-token"
+	server.Close()
+
+	err := PostWebhook(webhook, []byte(`{}`), RetryPolicy{Attempts: 2})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if strings.Contains(err.Error(), "secret-token") {
+		t.Errorf("error leaks webhook URL: %v", err)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{value: "", want: 0},
+		{value: "5", want: 5 * time.Second},
+		{value: " 2 ", want: 2 * time.Second},
+		{value: "60", want: time.Minute},
+		{value: "3600", want: maxRetryAfter},
+		{value: "0", want: 0},
+		{value: "-3", want: 0},
+		{value: "Wed, 21 Oct 2015 07:28:00 GMT", want: 0},
+	}
+	for _, tt := range tests {
+		if got := retryAfterInternal(tt.value); got != tt.want {
+			t.Errorf("retryAfterInternal(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
@@ -1,79 +1,161 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"trivy-parser/cli"
+	"trivy-parser/i18n"
 	"trivy-parser/io"
 	"trivy-parser/processor"
 )
 
 func main() {
+	// 하위 명령: 이력 저장소 적재 및 조회, HTTP 변환 서버
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case cli.CommandIngest:
+			runIngest(cli.ParseIngestFlags(os.Args[2:]))
+			return
+		case cli.CommandHistory:
+			runHistory(cli.ParseHistoryFlags(os.Args[2:]))
+			return
+		case cli.CommandServe:
+			runServe(cli.ParseServeFlags(os.Args[2:]))
+			return
+		}
+	}
+
 	// 1. CLI 플래그 파싱
 	config := cli.ParseFlags()
 
 	// 2. 입력 파일 읽기
-	data, inputSize, err := io.ReadFile(config.InputFile)
+	scans, inputSize, err := io.ReadFiles(config.InputFiles)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("cli.error", err))
+		os.Exit(1)
+	}
+	fmt.Println(i18n.T("cli.input", strings.Join(config.InputFiles, ", "), inputSize))
+
+	// 여러 스캔 입력은 Excel 모드에서만 지원
+	if len(scans) > 1 && !config.ExportExcel {
+		fmt.Fprintln(os.Stderr, i18n.T("cli.multi_input_excel"))
+		os.Exit(1)
+	}
+	data := scans[0]
+
+	// 위험 점수 경과일 및 조치 기한 계산용 이력
+	history, scannedAt, err := loadHistory(config, scans)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		fmt.Fprintln(os.Stderr, i18n.T("cli.error", err))
 		os.Exit(1)
 	}
-	fmt.Printf("Input:  %s (%.2f MB)\n", config.InputFile, inputSize)
 
 	// Excel 모드: Excel 파일로 내보내기
 	if config.ExportExcel {
-		excelData := processor.PrepareExcelData(data)
-		if err := io.WriteExcel(config.OutputFile, excelData); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		excelData := prepareExcel(config, scans, history, scannedAt, printWarning)
+		if err := io.WriteExcel(config.OutputFile, excelData, io.ExcelOptions{
+			Details: config.ExcelDetails,
+			Lang:    config.Lang,
+		}); err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("cli.error", err))
 			os.Exit(1)
 		}
-		fmt.Printf("Output: %s (Excel format)\n", config.OutputFile)
+		fmt.Println(i18n.T("cli.output_excel", config.OutputFile))
+		notify(config, scans, history, scannedAt, printWarning)
+		checkOverdueGate(config, excelData.SLA)
 		return
 	}
 
 	// Preprocess 모드: 그룹화 + 타겟별 분리
 	if config.Preprocess {
-		targetMap := processor.Preprocess(data)
-
-		if len(targetMap) == 0 {
-			fmt.Fprintf(os.Stderr, "Error: No .tf files found to process\n")
+		out, err := openPreprocessOutput(config, data)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("cli.error", err))
 			os.Exit(1)
 		}
-
-		// 출력 디렉토리 생성
-		if err := os.MkdirAll(config.OutputFile, 0755); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		result, err := writePreprocess(config, data, history, scannedAt, out, printWarning)
+		if err == nil {
+			err = out.Close()
+		}
+		if err != nil {
+			if bundle, ok := out.(*bundleOutput); ok {
+				bundle.discard()
+			}
+			if errors.Is(err, errNoTargets) {
+				fmt.Fprintln(os.Stderr, i18n.T("cli.no_tf_files"))
+				os.Exit(1)
+			}
+			fmt.Fprintln(os.Stderr, i18n.T("cli.error", err))
 			os.Exit(1)
 		}
+		outputPath := func(name string) string {
+			return filepath.Join(config.OutputFile, filepath.FromSlash(name))
+		}
 
-		// 타겟별로 파일 저장
-		var totalOutputSize float64
-		fileCount := 0
-		var filenames []string
-		for target, targetResult := range targetMap {
-			targetFilename := processor.GenerateTargetFilename(config.OutputFile, target)
-			outputSize, err := io.WriteFile(targetFilename, targetResult, config.Pretty)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error (%s): %v\n", target, err)
-				continue
+		// 정책 카탈로그, 리포트, 인덱스 안내
+		if result.Catalog != "" {
+			fmt.Println(i18n.T("cli.output_catalog", outputPath(result.Catalog)))
+		}
+		if result.Unowned != nil {
+			fmt.Println(i18n.T("cli.output_unowned", outputPath(processor.UnownedReportFilename), result.Unowned.Total))
+		}
+		if result.Priority != nil {
+			fmt.Println(i18n.T("cli.output_priority", outputPath(processor.PriorityReportFilename), result.Priority.Total))
+		}
+		if result.Overdue != nil {
+			fmt.Println(i18n.T("cli.output_overdue", outputPath(processor.OverdueReportFilename), result.Overdue.Total, result.Overdue.Tracked))
+		}
+		if result.Index != "" {
+			fmt.Println(i18n.T("cli.output_index", outputPath(result.Index)))
+		}
+		if config.Bundle != io.BundleNone {
+			if info, err := os.Stat(config.OutputFile); err == nil {
+				fmt.Println(i18n.T("cli.output_bundle", config.OutputFile, config.Bundle, float64(info.Size())/(1024*1024)))
 			}
-			totalOutputSize += outputSize
-			fileCount++
-			filenames = append(filenames, targetFilename)
+		}
+
+		// 접미사로 구분된 파일명 충돌 안내
+		for _, c := range result.Collisions {
+			fmt.Fprintln(os.Stderr, i18n.T("cli.collision_resolved", c.Key, c.Existing, outputPath(c.Resolved)))
 		}
 
 		// 통계 출력
-		fmt.Printf("Output: %d files -> %s\n", fileCount, config.OutputFile)
-		for _, filename := range filenames {
-			fmt.Printf("  - %s\n", filename)
+		fmt.Println(i18n.T("cli.output_files", len(result.Files), config.OutputFile))
+		for _, filename := range result.Files {
+			fmt.Printf("  - %s\n", outputPath(filename))
 		}
-		reduction := ((inputSize - totalOutputSize) / inputSize) * 100
-		fmt.Printf("Size reduction: %.1f%% (%.2f MB -> %.2f MB)\n",
-			reduction, inputSize, totalOutputSize)
+		reduction := ((inputSize - result.Size) / inputSize) * 100
+		fmt.Println(i18n.T("cli.size_reduction", reduction, inputSize, result.Size))
+		notify(config, scans, history, scannedAt, printWarning)
+		checkOverdueGate(config, result.Overdue)
 		return
 	}
 
 	// 모드가 지정되지 않은 경우 에러
-	fmt.Fprintf(os.Stderr, "Error: Please specify either -excel or -preprocess mode\n")
+	fmt.Fprintln(os.Stderr, i18n.T("cli.no_mode"))
 	os.Exit(1)
 }
+
+// printWarning은 경고 메시지를 표준 에러로 출력합니다.
+func printWarning(message string) {
+	fmt.Fprintln(os.Stderr, message)
+}
+
+// checkOverdueGate는 -fail-on-overdue 게이트 조건에 해당하는 기한 초과 finding이 있으면 종료 코드 3으로 종료합니다.
+// 출력 파일은 이미 저장된 상태이므로 CI에서 결과물을 그대로 수집할 수 있습니다.
+func checkOverdueGate(config *cli.Config, report *processor.SLAReport) {
+	if config.OverdueGate == nil || report == nil {
+		return
+	}
+	if count := report.CountOverdue(config.OverdueGate); count > 0 {
+		scope := "all"
+		if len(config.OverdueGate) > 0 {
+			scope = strings.Join(config.OverdueGate, ",")
+		}
+		fmt.Fprintln(os.Stderr, i18n.T("cli.sla_gate_failed", count, scope))
+		os.Exit(3)
+	}
+}
@@ -0,0 +1,358 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+	"trivy-parser/cli"
+	"trivy-parser/i18n"
+	"trivy-parser/io"
+	"trivy-parser/processor"
+)
+
+// errNoTargets는 preprocess로 분리할 .tf 타겟이 없을 때의 에러입니다.
+var errNoTargets = errors.New("no .tf targets in input")
+
+// loadHistory는 위험 점수 경과일, 조치 기한, 알림 신규 finding 판단용 이력을 만듭니다.
+// -baseline 이전 스캔 + -history-db + 입력 스캔을 합치며, 기준 시각은 가장 최근 스캔입니다.
+func loadHistory(config *cli.Config, scans []*processor.TrivyResult) (processor.FindingHistory, time.Time, error) {
+	if config.Risk == nil && config.SLA == nil && config.Notifier == nil {
+		return nil, time.Time{}, nil
+	}
+	baseline, _, err := io.ReadFiles(config.BaselineFiles)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	latest := processor.LatestScan(scans)
+	history := processor.BuildFindingHistory(append(baseline, scans...))
+	if config.HistoryDB != "" {
+		stored, err := io.ReadHistoryDB(config.HistoryDB, latest.ArtifactName)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		history.Merge(stored)
+	}
+	scannedAt, _ := processor.ParseCreatedAt(latest.CreatedAt)
+	return history, scannedAt, nil
+}
+
+// prepareExcel은 스캔 결과를 Excel 데이터로 변환하고 옵션에 따른 정보를 첨부합니다.
+// 여러 스캔이면 CreatedAt 순으로 정렬하여 최신 결과 + Trend 시트를 만듭니다. 경고는 warn으로 전달합니다.
+func prepareExcel(config *cli.Config, scans []*processor.TrivyResult, history processor.FindingHistory, scannedAt time.Time, warn func(string)) *processor.ExcelData {
+	excelData := processor.PrepareExcelData(scans[0], config.Classifier)
+	if len(scans) > 1 {
+		excelData = processor.PrepareTrendExcelData(scans, config.Classifier)
+	}
+	if config.Terraform != nil {
+		config.Terraform.EnrichExcelData(excelData, config.TerraformOptions)
+	}
+	if config.Compliance != nil {
+		config.Compliance.EnrichExcelData(excelData)
+	}
+	if config.PolicyMetadata != nil {
+		config.PolicyMetadata.EnrichExcelData(excelData)
+	}
+	if config.CodeOwners != nil {
+		config.CodeOwners.AssignExcelData(excelData)
+	}
+	if config.Blamer != nil {
+		stats := processor.AttachBlameExcel(excelData, config.Blamer)
+		for _, target := range stats.Failed {
+			warn(i18n.T("cli.blame_failed", target))
+		}
+	}
+	if config.Risk != nil {
+		config.Risk.ScoreExcelData(excelData, history, scannedAt)
+	}
+	if config.SLA != nil {
+		config.SLA.ApplyExcelData(excelData, history, scannedAt, config.SLAAsOf)
+	}
+	processor.SortExcelData(excelData, config.SortKeys)
+	return excelData
+}
+
+// preprocessResult는 preprocess 출력 저장 결과입니다. 파일 이름은 출력 루트 기준 상대 경로입니다.
+type preprocessResult struct {
+	Files    []string // 저장한 타겟 파일
+	Size     float64  // 타겟 파일과 리포트 파일 크기 합계 (MB, 인덱스 제외)
+	Catalog  string   // 정책 카탈로그 (-normalize, 없으면 빈 값)
+	Unowned  *processor.UnownedReport
+	Priority *processor.PriorityReport
+	Overdue  *processor.SLAReport
+	Index    string // 인덱스 (-index, 없으면 빈 값)
+
+	// 접미사로 구분된 파일명 충돌 (-on-collision suffix)
+	Collisions []processor.Collision
+}
+
+// writePreprocess는 정책별 그룹화 + 타겟별 분리 결과를 out에 저장합니다.
+// 타겟 파일 저장 실패 등 경고는 warn으로 전달하고 계속 진행합니다.
+func writePreprocess(config *cli.Config, data *processor.TrivyResult, history processor.FindingHistory, scannedAt time.Time, out io.OutputWriter, warn func(string)) (*preprocessResult, error) {
+	targetMap := processor.Preprocess(data, config.Classifier)
+	if len(targetMap) == 0 {
+		return nil, errNoTargets
+	}
+
+	// 로컬 .tf 리소스 정보(태그, 모듈 디렉터리) 첨부 및 태그 필터/가중치 적용
+	if config.Terraform != nil {
+		config.Terraform.EnrichResults(targetMap, config.TerraformOptions)
+	}
+
+	// 정책별 컴플라이언스 통제 항목 참조 추가
+	if config.Compliance != nil {
+		config.Compliance.EnrichResults(targetMap)
+	}
+
+	// 로컬 Rego 정책의 METADATA 병합 (담당 팀, 조치 예시 등)
+	if config.PolicyMetadata != nil {
+		config.PolicyMetadata.EnrichResults(targetMap)
+	}
+
+	// 로컬 체크아웃에서 원인 라인 주변 소스 첨부
+	if config.SourceRoot != "" {
+		sources, err := io.ReadSources(config.SourceRoot, processor.ResultTargets(targetMap))
+		if err != nil {
+			return nil, err
+		}
+		stats := processor.AttachSources(targetMap, sources, config.SourceOptions)
+		for _, target := range stats.Missing {
+			warn(i18n.T("cli.source_missing", target, config.SourceRoot))
+		}
+		for _, target := range stats.Mismatched {
+			warn(i18n.T("cli.source_mismatch", target))
+		}
+	}
+
+	// git blame: 라인 범위를 마지막으로 수정한 커밋 정보 첨부
+	if config.Blamer != nil {
+		stats := processor.AttachBlame(targetMap, config.Blamer)
+		for _, target := range stats.Failed {
+			warn(i18n.T("cli.blame_failed", target))
+		}
+	}
+
+	result := &preprocessResult{}
+
+	// 위험 점수 계산 및 우선순위 목록 (담당자별 분리 전 타겟 기준으로 집계)
+	if config.Risk != nil {
+		result.Priority = config.Risk.ScoreResults(targetMap, history, scannedAt)
+	}
+
+	// 조치 기한 및 기한 초과 목록
+	if config.SLA != nil {
+		result.Overdue = config.SLA.ApplyResults(targetMap, history, scannedAt, config.SLAAsOf)
+	}
+
+	// 위험 점수 등 첨부 정보로 정렬할 수 있도록 첨부 후 정렬
+	for _, targetResult := range targetMap {
+		processor.SortGroupedResult(targetResult, config.SortKeys)
+	}
+
+	// CODEOWNERS 담당자 지정 및 담당자 없음 리포트 (담당자별 분리 전 타겟 기준으로 집계)
+	if config.CodeOwners != nil {
+		config.CodeOwners.AssignResults(targetMap)
+		result.Unowned = processor.SummarizeUnowned(targetMap)
+		if config.SplitByOwner {
+			targetMap = config.CodeOwners.SplitByOwner(targetMap)
+		}
+	}
+
+	// 청크 모드: 타겟별 파일 대신 토큰 예산 단위로 묶거나 분할
+	if config.ChunkBudget > 0 {
+		var err error
+		targetMap, err = processor.ChunkResults(targetMap, processor.ChunkOptions{
+			Budget:     config.ChunkBudget,
+			Tokenizer:  config.Tokenizer,
+			Pretty:     config.Pretty,
+			Projection: config.Projection,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 렌더링 모드: JSON 대신 템플릿으로 렌더링한 텍스트(.md) 저장
+	var renderedText map[string]string
+	if config.Renderer != nil {
+		rendered, err := config.Renderer.Render(targetMap)
+		if err != nil {
+			return nil, err
+		}
+		targetMap = make(map[string]*processor.GroupedTrivyResult, len(rendered))
+		renderedText = make(map[string]string, len(rendered))
+		for _, r := range rendered {
+			targetMap[r.Key] = r.Result
+			renderedText[r.Key] = r.Text
+		}
+	}
+
+	// 파일명을 먼저 모두 결정하여 충돌 시 아무것도 쓰기 전에 실패 처리
+	targets := processor.SortedTargetKeys(targetMap)
+	targetFilenames := make(map[string]string, len(targets))
+	filenameGen := processor.NewFilenameGenerator("", config.FilenameScheme, config.OnCollision)
+	if renderedText != nil {
+		filenameGen.SetExtension(".md")
+	}
+	for _, target := range targets {
+		targetFilename, err := filenameGen.Generate(target)
+		if err != nil {
+			return nil, err
+		}
+		targetFilenames[target] = filepath.ToSlash(targetFilename)
+	}
+
+	// 타겟별로 파일 저장 (타겟 이름 순으로 저장하여 실행마다 동일한 순서 보장)
+	manifest := processor.NewManifest(data)
+	for _, target := range targets {
+		targetResult := targetMap[target]
+		targetFilename := targetFilenames[target]
+
+		var written *io.WrittenFile
+		var err error
+		switch {
+		case renderedText != nil:
+			written, err = out.WriteText(targetFilename, renderedText[target])
+		case config.Normalize:
+			// 정책 메타데이터는 카탈로그로 분리하고 정책 ID만 참조
+			catalogRel, _ := filepath.Rel(filepath.Dir(targetFilename), processor.PolicyCatalogFilename)
+			normalized := processor.NormalizeResult(targetResult, filepath.ToSlash(catalogRel))
+			written, err = out.WriteJSON(targetFilename, normalized, config.Pretty)
+		case config.GroupBy != processor.GroupByPolicy:
+			// 리소스/서비스/프로바이더 기준으로 다시 그룹화
+			regrouped := processor.GroupByAxis(targetResult, config.GroupBy)
+			processor.SortResourceGroupedResult(regrouped, config.SortKeys)
+			written, err = out.WriteJSON(targetFilename, regrouped, config.Pretty)
+		case config.Projection != nil:
+			// 필드 선택 규칙 적용
+			written, err = out.WriteJSON(targetFilename, config.Projection.Apply(targetResult), config.Pretty)
+		default:
+			written, err = out.WriteJSON(targetFilename, targetResult, config.Pretty)
+		}
+		if err != nil {
+			warn(i18n.T("cli.error_target", target, err))
+			continue
+		}
+		result.Size += float64(written.Size) / (1024 * 1024)
+		result.Files = append(result.Files, targetFilename)
+		manifest.AddFile(target, targetFilename, targetResult, written.Size, written.SHA256)
+	}
+
+	// 정규화 출력: 정책 카탈로그 저장
+	if config.Normalize && renderedText == nil {
+		if err := writeReportInternal(out, processor.PolicyCatalogFilename, processor.BuildPolicyCatalog(targetMap), config.Pretty, &result.Size); err != nil {
+			return nil, err
+		}
+		result.Catalog = processor.PolicyCatalogFilename
+	}
+
+	// 담당자 없음 리포트, 우선순위 목록, 기한 초과 리포트 저장
+	if result.Unowned != nil {
+		if err := writeReportInternal(out, processor.UnownedReportFilename, result.Unowned, config.Pretty, &result.Size); err != nil {
+			return nil, err
+		}
+	}
+	if result.Priority != nil {
+		if err := writeReportInternal(out, processor.PriorityReportFilename, result.Priority, config.Pretty, &result.Size); err != nil {
+			return nil, err
+		}
+	}
+	if result.Overdue != nil {
+		if err := writeReportInternal(out, processor.OverdueReportFilename, result.Overdue, config.Pretty, &result.Size); err != nil {
+			return nil, err
+		}
+	}
+
+	// 생성된 파일 목록 인덱스 저장
+	if config.WriteIndex {
+		if _, err := out.WriteJSON(processor.ManifestFilename, manifest, config.Pretty); err != nil {
+			return nil, err
+		}
+		result.Index = processor.ManifestFilename
+	}
+
+	result.Collisions = filenameGen.Collisions()
+	return result, nil
+}
+
+// notify는 최신 스캔 요약을 채팅 웹후크로 전송합니다 (-notify-dry-run이면 페이로드를 표준 출력으로 출력).
+// 결과물은 이미 저장된 상태이므로 전송 실패는 경고로만 알립니다.
+func notify(config *cli.Config, scans []*processor.TrivyResult, history processor.FindingHistory, scannedAt time.Time, warn func(string)) {
+	if config.Notifier == nil {
+		return
+	}
+	summary := processor.SummarizeNotification(processor.LatestScan(scans), config.Classifier, history, scannedAt, config.NotifyOptions)
+	payload, err := config.Notifier.Payload(summary, config.NotifyDryRun)
+	if err != nil {
+		warn(i18n.T("cli.notify_failed", err))
+		return
+	}
+	if config.NotifyDryRun {
+		fmt.Println(i18n.T("cli.notify_dry_run", config.Notifier.Format()))
+		fmt.Println(string(payload))
+		return
+	}
+	if err := io.PostWebhook(config.NotifyWebhook, payload, config.NotifyRetry); err != nil {
+		warn(i18n.T("cli.notify_failed", err))
+		return
+	}
+	fmt.Println(i18n.T("cli.notify_sent", config.Notifier.Format(), summary.Total, summary.New))
+}
+
+// bundleOutput은 -bundle 압축 파일 출력입니다.
+type bundleOutput struct {
+	io.OutputWriter
+	file *os.File
+}
+
+// openPreprocessOutput은 -bundle 여부에 따라 출력 디렉토리 또는 압축 파일(-output 경로)에 쓰는 OutputWriter를 만듭니다.
+// 압축 파일 항목은 디스크에 임시 저장하지 않고 바로 기록하며, 수정 시각은 스캔 CreatedAt(없으면 현재 시각)을 사용합니다.
+func openPreprocessOutput(config *cli.Config, data *processor.TrivyResult) (io.OutputWriter, error) {
+	if config.Bundle == io.BundleNone {
+		return io.NewDirWriter(config.OutputFile)
+	}
+	modified, err := processor.ParseCreatedAt(data.CreatedAt)
+	if err != nil {
+		modified = time.Now()
+	}
+	if err := os.MkdirAll(filepath.Dir(config.OutputFile), 0755); err != nil {
+		return nil, err
+	}
+	file, err := os.Create(config.OutputFile)
+	if err != nil {
+		return nil, err
+	}
+	out, err := io.NewBundleWriter(file, config.Bundle, modified)
+	if err != nil {
+		file.Close()
+		os.Remove(config.OutputFile)
+		return nil, err
+	}
+	return &bundleOutput{OutputWriter: out, file: file}, nil
+}
+
+// Close는 압축 파일을 마무리하고 닫습니다.
+func (b *bundleOutput) Close() error {
+	if err := b.OutputWriter.Close(); err != nil {
+		b.file.Close()
+		return err
+	}
+	return b.file.Close()
+}
+
+// discard는 실패한 압축 파일을 닫고 삭제합니다 (잘린 압축 파일을 남기지 않음).
+func (b *bundleOutput) discard() {
+	b.file.Close()
+	os.Remove(b.file.Name())
+}
+
+// writeReportInternal은 리포트 파일을 저장하고 크기(MB)를 size에 더합니다.
+func writeReportInternal(out io.OutputWriter, name string, report interface{}, pretty bool, size *float64) error {
+	written, err := out.WriteJSON(name, report, pretty)
+	if err != nil {
+		return err
+	}
+	*size += float64(written.Size) / (1024 * 1024)
+	return nil
+}
@@ -0,0 +1,239 @@
+package processor
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BlameLine은 git blame 결과의 라인 1개입니다.
+type BlameLine struct {
+	Commit  string
+	Author  string
+	Email   string
+	Time    time.Time // 작성 시각 (작성자 시간대)
+	Summary string    // 커밋 메시지 첫 줄
+}
+
+// Blamer는 파일별 blame 결과를 제공합니다.
+// 반환하는 슬라이스의 인덱스 i는 라인 번호 i+1입니다.
+type Blamer interface {
+	BlameFile(target string) ([]BlameLine, error)
+}
+
+// BlameInfo는 Violation 라인 범위를 마지막으로 수정한 커밋 정보입니다.
+type BlameInfo struct {
+	Commit  string   `json:"Commit"`
+	Author  string   `json:"Author"`
+	Email   string   `json:"Email"`
+	Date    string   `json:"Date"` // RFC 3339
+	Summary string   `json:"Summary"`
+	Authors []string `json:"Authors,omitempty"` // 라인 범위를 수정한 작성자가 여럿이면 전체 목록
+}
+
+// BlameStats는 blame 첨부 결과 집계입니다.
+type BlameStats struct {
+	Attached int      // blame 정보를 첨부한 Violation 수
+	Failed   []string // blame을 가져오지 못한 타겟 목록 (추적되지 않는 파일 등)
+}
+
+// isBlameCommitInternal은 커밋 ID가 SHA-1(40자) 또는 SHA-256(64자) 16진수인지 확인합니다.
+func isBlameCommitInternal(commit string) bool {
+	if len(commit) != 40 && len(commit) != 64 {
+		return false
+	}
+	for i := 0; i < len(commit); i++ {
+		if c := commit[i]; !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// isUncommittedInternal은 git blame이 커밋되지 않은 라인에 사용하는 커밋 ID(모두 0)인지 확인합니다.
+func isUncommittedInternal(commit string) bool {
+	return strings.Trim(commit, "0") == ""
+}
+
+// ParseBlamePorcelain은 `git blame --porcelain` 출력을 라인 번호 순 목록으로 파싱합니다.
+// porcelain 형식은 커밋 정보를 처음 등장할 때만 출력하므로 커밋별로 기억해 둡니다.
+func ParseBlamePorcelain(output []byte) ([]BlameLine, error) {
+	commits := make(map[string]*BlameLine)
+	var lines []BlameLine
+	var current *BlameLine
+	var currentLine int
+	var authorTime int64
+	var authorTZ string
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		text := scanner.Text()
+
+		// 원본 라인 내용: 현재 라인 확정
+		if strings.HasPrefix(text, "\t") {
+			if current == nil {
+				return nil, fmt.Errorf("invalid blame output: content before header")
+			}
+			if authorTZ != "" {
+				current.Time = parseBlameTimeInternal(authorTime, authorTZ)
+				authorTZ = ""
+			}
+			for len(lines) < currentLine {
+				lines = append(lines, BlameLine{})
+			}
+			lines[currentLine-1] = *current
+			current = nil
+			continue
+		}
+
+		key, value, _ := strings.Cut(text, " ")
+		if current == nil {
+			// 헤더: <커밋 (SHA-1 또는 SHA-256)> <원본 라인> <최종 라인> [<라인 수>]
+			fields := strings.Fields(text)
+			if len(fields) < 3 || !isBlameCommitInternal(fields[0]) {
+				return nil, fmt.Errorf("invalid blame header: %q", text)
+			}
+			number, err := strconv.Atoi(fields[2])
+			if err != nil || number < 1 {
+				return nil, fmt.Errorf("invalid blame header: %q", text)
+			}
+			currentLine = number
+			if commits[fields[0]] == nil {
+				commits[fields[0]] = &BlameLine{Commit: fields[0]}
+			}
+			current = commits[fields[0]]
+			continue
+		}
+
+		switch key {
+		case "author":
+			current.Author = value
+		case "author-mail":
+			current.Email = strings.Trim(value, "<>")
+		case "author-time":
+			authorTime, _ = strconv.ParseInt(value, 10, 64)
+		case "author-tz":
+			authorTZ = value
+		case "summary":
+			current.Summary = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// parseBlameTimeInternal은 author-time(유닉스 시각)과 author-tz(+0900 형식)로 시각을 만듭니다.
+func parseBlameTimeInternal(unix int64, tz string) time.Time {
+	t := time.Unix(unix, 0).UTC()
+	if offset, err := time.Parse("-0700", tz); err == nil {
+		_, seconds := offset.Zone()
+		t = t.In(time.FixedZone(tz, seconds))
+	}
+	return t
+}
+
+// blameRangeInternal은 startLine~endLine 중 가장 최근에 수정된 라인의 커밋 정보를 반환합니다.
+// 커밋되지 않은 라인은 제외하며, 범위 내 라인이 모두 커밋되지 않았으면 nil을 반환합니다.
+func blameRangeInternal(lines []BlameLine, startLine, endLine int) *BlameInfo {
+	var latest *BlameLine
+	seen := make(map[string]bool)
+	var authors []string
+	for number := startLine; number <= endLine && number <= len(lines); number++ {
+		if number < 1 {
+			continue
+		}
+		line := &lines[number-1]
+		if isUncommittedInternal(line.Commit) {
+			continue
+		}
+		if latest == nil || line.Time.After(latest.Time) {
+			latest = line
+		}
+		if !seen[line.Author] {
+			seen[line.Author] = true
+			authors = append(authors, line.Author)
+		}
+	}
+	if latest == nil {
+		return nil
+	}
+
+	info := &BlameInfo{
+		Commit:  latest.Commit,
+		Author:  latest.Author,
+		Email:   latest.Email,
+		Date:    latest.Time.Format(time.RFC3339),
+		Summary: latest.Summary,
+	}
+	if len(authors) > 1 {
+		sort.Strings(authors)
+		info.Authors = authors
+	}
+	return info
+}
+
+// AttachBlame은 Preprocess 결과의 각 Violation에 라인 범위의 blame 정보(Blame)를 첨부합니다.
+// 같은 타겟은 Blamer에 한 번만 요청합니다.
+func AttachBlame(targetMap map[string]*GroupedTrivyResult, blamer Blamer) BlameStats {
+	var stats BlameStats
+	failed := make(map[string]bool)
+
+	for _, key := range SortedTargetKeys(targetMap) {
+		for r := range targetMap[key].Results {
+			result := &targetMap[key].Results[r]
+			lines, err := blamer.BlameFile(result.Target)
+			if err != nil {
+				failed[result.Target] = true
+				continue
+			}
+
+			for i := range result.Misconfigurations {
+				violations := result.Misconfigurations[i].Violations
+				for v := range violations {
+					violations[v].Blame = blameRangeInternal(lines, violations[v].StartLine, violations[v].EndLine)
+					if violations[v].Blame != nil {
+						stats.Attached++
+					}
+				}
+			}
+		}
+	}
+
+	stats.Failed = sortedSetInternal(failed)
+	return stats
+}
+
+// AttachBlameExcel은 Excel 행에 라인 범위의 blame 정보를 채웁니다.
+func AttachBlameExcel(data *ExcelData, blamer Blamer) BlameStats {
+	var stats BlameStats
+	failed := make(map[string]bool)
+
+	data.Blame = true
+	for c := range data.Categories {
+		rows := data.Categories[c].Rows
+		for i := range rows {
+			lines, err := blamer.BlameFile(rows[i].Target)
+			if err != nil {
+				failed[rows[i].Target] = true
+				continue
+			}
+
+			if info := blameRangeInternal(lines, rows[i].StartLine, rows[i].EndLine); info != nil {
+				rows[i].BlameCommit = info.Commit
+				rows[i].BlameAuthor = info.Author
+				rows[i].BlameDate = info.Date
+				stats.Attached++
+			}
+		}
+	}
+
+	stats.Failed = sortedSetInternal(failed)
+	return stats
+}
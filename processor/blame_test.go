@@ -0,0 +1,204 @@
+package processor
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// git blame --porcelain 출력 (SHA-1 저장소, 마지막 라인은 커밋되지 않은 변경)
+const blamePorcelainSHA1 = `2a21123b32d45a68e3fde30ea777f6beff2593f7 1 1 1
+author Kim Min
+author-mail <kim@example.com>
+author-time 1704132245
+author-tz +0900
+committer Kim Min
+committer-mail <kim@example.com>
+committer-time 1704132245
+committer-tz +0900
+summary initial
+boundary
+filename f.tf
+	a
+b96c6a80b9ee91bbe68a4d8711ca555fb2132be1 2 2 2
+author Lee
+author-mail <lee@example.com>
+author-time 1706972400
+author-tz -0500
+committer Lee
+committer-mail <lee@example.com>
+committer-time 1706972400
+committer-tz -0500
+summary update b and c
+previous 2a21123b32d45a68e3fde30ea777f6beff2593f7 f.tf
+filename f.tf
+	B
+b96c6a80b9ee91bbe68a4d8711ca555fb2132be1 3 3
+	C
+2a21123b32d45a68e3fde30ea777f6beff2593f7 4 4 1
+	d
+b96c6a80b9ee91bbe68a4d8711ca555fb2132be1 5 5 1
+	e
+0000000000000000000000000000000000000000 6 6 1
+author Not Committed Yet
+author-mail <not.committed.yet>
+author-time 1792409252
+author-tz +0000
+committer Not Committed Yet
+committer-mail <not.committed.yet>
+committer-time 1792409252
+committer-tz +0000
+summary Version of f.tf from f.tf
+previous b96c6a80b9ee91bbe68a4d8711ca555fb2132be1 f.tf
+filename f.tf
+	wip
+`
+
+// git blame --porcelain 출력 (git init --object-format=sha256 저장소)
+const blamePorcelainSHA256 = `f2b86e81185d2621dc0ca9886702aee5ad4117e567c8c01188e59d2286e8f70f 1 1 2
+author Park
+author-mail <park@example.com>
+author-time 1709251200
+author-tz +0000
+committer Park
+committer-mail <park@example.com>
+committer-time 1709251200
+committer-tz +0000
+summary sha256 repo
+boundary
+filename m.tf
+	x
+f2b86e81185d2621dc0ca9886702aee5ad4117e567c8c01188e59d2286e8f70f 2 2
+	y
+`
+
+const (
+	blameCommitKim  = "2a21123b32d45a68e3fde30ea777f6beff2593f7"
+	blameCommitLee  = "b96c6a80b9ee91bbe68a4d8711ca555fb2132be1"
+	blameCommitPark = "f2b86e81185d2621dc0ca9886702aee5ad4117e567c8c01188e59d2286e8f70f"
+)
+
+// blameSummaryInternal은 비교하기 쉽도록 "<커밋 앞 7자> <작성자> <시각> <요약>" 형식으로 만듭니다.
+func blameSummaryInternal(lines []BlameLine) []string {
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		out = append(out, strings.Join([]string{line.Commit[:7], line.Author, line.Email, line.Time.Format(time.RFC3339), line.Summary}, " | "))
+	}
+	return out
+}
+
+func TestParseBlamePorcelain(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   []string
+	}{
+		{
+			name:   "sha1 with repeated headers, boundary, previous and uncommitted",
+			output: blamePorcelainSHA1,
+			want: []string{
+				"2a21123 | Kim Min | kim@example.com | 2024-01-02T03:04:05+09:00 | initial",
+				"b96c6a8 | Lee | lee@example.com | 2024-02-03T10:00:00-05:00 | update b and c",
+				"b96c6a8 | Lee | lee@example.com | 2024-02-03T10:00:00-05:00 | update b and c",
+				"2a21123 | Kim Min | kim@example.com | 2024-01-02T03:04:05+09:00 | initial",
+				"b96c6a8 | Lee | lee@example.com | 2024-02-03T10:00:00-05:00 | update b and c",
+				"0000000 | Not Committed Yet | not.committed.yet | 2026-10-19T11:27:32Z | Version of f.tf from f.tf",
+			},
+		},
+		{
+			name:   "sha256 with multi-line group",
+			output: blamePorcelainSHA256,
+			want: []string{
+				"f2b86e8 | Park | park@example.com | 2024-03-01T00:00:00Z | sha256 repo",
+				"f2b86e8 | Park | park@example.com | 2024-03-01T00:00:00Z | sha256 repo",
+			},
+		},
+		{
+			name:   "empty file",
+			output: "",
+			want:   []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, err := ParseBlamePorcelain([]byte(tt.output))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := blameSummaryInternal(lines); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("lines =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestParseBlamePorcelainInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+	}{
+		{name: "content before header", output: "\tline\n"},
+		{name: "abbreviated commit", output: "2a21123 1 1 1\n\ta\n"},
+		{name: "uppercase commit", output: strings.ToUpper(blameCommitKim) + " 1 1 1\n\ta\n"},
+		{name: "commit of unknown length", output: blameCommitKim + "00 1 1 1\n\ta\n"},
+		{name: "missing line number", output: blameCommitKim + " 1\n\ta\n"},
+		{name: "invalid line number", output: blameCommitKim + " 1 0 1\n\ta\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseBlamePorcelain([]byte(tt.output)); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestBlameRange(t *testing.T) {
+	sha1Lines, err := ParseBlamePorcelain([]byte(blamePorcelainSHA1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sha256Lines, err := ParseBlamePorcelain([]byte(blamePorcelainSHA256 + strings.Repeat("0", 64) + " 3 3 1\nauthor Not Committed Yet\nauthor-time 1792409252\nauthor-tz +0000\n\tz\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		lines      []BlameLine
+		start, end int
+		want       *BlameInfo
+	}{
+		{
+			name:  "latest commit in range with all authors",
+			lines: sha1Lines, start: 1, end: 4,
+			want: &BlameInfo{Commit: blameCommitLee, Author: "Lee", Email: "lee@example.com", Date: "2024-02-03T10:00:00-05:00", Summary: "update b and c", Authors: []string{"Kim Min", "Lee"}},
+		},
+		{
+			name:  "single author",
+			lines: sha1Lines, start: 4, end: 4,
+			want: &BlameInfo{Commit: blameCommitKim, Author: "Kim Min", Email: "kim@example.com", Date: "2024-01-02T03:04:05+09:00", Summary: "initial"},
+		},
+		{
+			name:  "uncommitted line is skipped",
+			lines: sha1Lines, start: 5, end: 10,
+			want: &BlameInfo{Commit: blameCommitLee, Author: "Lee", Email: "lee@example.com", Date: "2024-02-03T10:00:00-05:00", Summary: "update b and c"},
+		},
+		{name: "only uncommitted lines", lines: sha1Lines, start: 6, end: 6},
+		{name: "range outside file", lines: sha1Lines, start: 20, end: 30},
+		{
+			name:  "sha256 commit and uncommitted line",
+			lines: sha256Lines, start: 0, end: 3,
+			want: &BlameInfo{Commit: blameCommitPark, Author: "Park", Email: "park@example.com", Date: "2024-03-01T00:00:00Z", Summary: "sha256 repo"},
+		},
+		{name: "sha256 uncommitted only", lines: sha256Lines, start: 3, end: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := blameRangeInternal(tt.lines, tt.start, tt.end); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("blameRangeInternal = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package processor
+
+import "fmt"
+
+// PolicyCatalogFilename은 정규화 출력 시 정책 메타데이터를 모아 저장하는 파일 이름입니다.
+const PolicyCatalogFilename = "policies.json"
+
+// PolicyCatalog는 정책 ID를 키로 하는 정책 메타데이터 모음입니다.
+type PolicyCatalog map[string]PolicyMetadata
+
+// PolicyMetadata는 타겟과 무관하게 정책마다 동일한 메타데이터입니다.
+type PolicyMetadata struct {
+	ID          string `json:"ID"`
+	Title       string `json:"Title"`
+	Description string `json:"Description"`
+	Namespace   string `json:"Namespace"`
+	Resolution  string `json:"Resolution"`
+	Severity    string `json:"Severity"`
+	PrimaryURL  string `json:"PrimaryURL"`
+
+	Compliance map[string][]string `json:"Compliance,omitempty"`
+
+	Owner               string   `json:"Owner,omitempty"`
+	RemediationExamples string   `json:"RemediationExamples,omitempty"`
+	RelatedResources    []string `json:"RelatedResources,omitempty"`
+	RiskRationale       string   `json:"RiskRationale,omitempty"`
+}
+
+// 정규화된 결과 구조체 (정책 메타데이터 대신 정책 ID만 참조)
+type NormalizedTrivyResult struct {
+	SchemaVersion   int                `json:"SchemaVersion"`
+	CreatedAt       string             `json:"CreatedAt"`
+	ArtifactName    string             `json:"ArtifactName"`
+	ArtifactType    string             `json:"ArtifactType"`
+	PolicyCatalog   string             `json:"PolicyCatalog"` // 이 파일 기준 정책 카탈로그 상대 경로
+	Owner           string             `json:"Owner,omitempty"`
+	SeveritySummary *SeveritySummary   `json:"SeveritySummary,omitempty"`
+	Results         []NormalizedResult `json:"Results"`
+}
+
+type NormalizedResult struct {
+	Target            string         `json:"Target"`
+	Class             string         `json:"Class"`
+	Type              string         `json:"Type"`
+	MisconfSummary    MisconfSummary `json:"MisconfSummary"`
+	Misconfigurations []PolicyRef    `json:"Misconfigurations,omitempty"`
+}
+
+// PolicyRef는 정책 카탈로그의 ID를 참조하는 정책 검출 결과입니다.
+type PolicyRef struct {
+	ID         string      `json:"ID"`
+	Status     string      `json:"Status"`
+	Violations []Violation `json:"Violations"`
+}
+
+// BuildPolicyCatalog는 Preprocess 결과에 포함된 모든 정책의 메타데이터를 수집합니다.
+// 같은 ID가 여러 번 나오면 처음 나온 메타데이터를 사용합니다.
+func BuildPolicyCatalog(targetMap map[string]*GroupedTrivyResult) PolicyCatalog {
+	catalog := make(PolicyCatalog)
+	for _, key := range SortedTargetKeys(targetMap) {
+		for _, result := range targetMap[key].Results {
+			for _, misconfig := range result.Misconfigurations {
+				if _, exists := catalog[misconfig.ID]; exists {
+					continue
+				}
+				catalog[misconfig.ID] = PolicyMetadata{
+					ID:          misconfig.ID,
+					Title:       misconfig.Title,
+					Description: misconfig.Description,
+					Namespace:   misconfig.Namespace,
+					Resolution:  misconfig.Resolution,
+					Severity:    misconfig.Severity,
+					PrimaryURL:  misconfig.PrimaryURL,
+					Compliance:  misconfig.Compliance,
+
+					Owner:               misconfig.Owner,
+					RemediationExamples: misconfig.RemediationExamples,
+					RelatedResources:    misconfig.RelatedResources,
+					RiskRationale:       misconfig.RiskRationale,
+				}
+			}
+		}
+	}
+	return catalog
+}
+
+// NormalizeResult는 그룹화된 결과에서 정책 메타데이터를 제거하고 정책 ID 참조만 남깁니다.
+// catalogPath는 출력 파일 기준 정책 카탈로그 상대 경로입니다.
+func NormalizeResult(input *GroupedTrivyResult, catalogPath string) *NormalizedTrivyResult {
+	normalized := &NormalizedTrivyResult{
+		SchemaVersion:   input.SchemaVersion,
+		CreatedAt:       input.CreatedAt,
+		ArtifactName:    input.ArtifactName,
+		ArtifactType:    input.ArtifactType,
+		PolicyCatalog:   catalogPath,
+		Owner:           input.Owner,
+		SeveritySummary: input.SeveritySummary,
+		Results:         make([]NormalizedResult, 0, len(input.Results)),
+	}
+
+	for _, result := range input.Results {
+		refs := make([]PolicyRef, 0, len(result.Misconfigurations))
+		for _, misconfig := range result.Misconfigurations {
+			refs = append(refs, PolicyRef{
+				ID:         misconfig.ID,
+				Status:     misconfig.Status,
+				Violations: misconfig.Violations,
+			})
+		}
+
+		normalized.Results = append(normalized.Results, NormalizedResult{
+			Target:            result.Target,
+			Class:             result.Class,
+			Type:              result.Type,
+			MisconfSummary:    result.MisconfSummary,
+			Misconfigurations: refs,
+		})
+	}
+
+	return normalized
+}
+
+// RehydrateResult는 정규화된 결과를 정책 카탈로그로 채워 원래 그룹화 형태로 복원합니다.
+// 카탈로그에 없는 정책 ID가 있으면 에러를 반환합니다.
+func RehydrateResult(input *NormalizedTrivyResult, catalog PolicyCatalog) (*GroupedTrivyResult, error) {
+	grouped := &GroupedTrivyResult{
+		SchemaVersion:   input.SchemaVersion,
+		CreatedAt:       input.CreatedAt,
+		ArtifactName:    input.ArtifactName,
+		ArtifactType:    input.ArtifactType,
+		Owner:           input.Owner,
+		SeveritySummary: input.SeveritySummary,
+		Results:         make([]GroupedResult, 0, len(input.Results)),
+	}
+
+	for _, result := range input.Results {
+		misconfigs := make([]GroupedMisconfiguration, 0, len(result.Misconfigurations))
+		for _, ref := range result.Misconfigurations {
+			policy, exists := catalog[ref.ID]
+			if !exists {
+				return nil, fmt.Errorf("policy %q not found in catalog (target %s)", ref.ID, result.Target)
+			}
+			misconfigs = append(misconfigs, GroupedMisconfiguration{
+				ID:          policy.ID,
+				Title:       policy.Title,
+				Description: policy.Description,
+				Namespace:   policy.Namespace,
+				Resolution:  policy.Resolution,
+				Severity:    policy.Severity,
+				PrimaryURL:  policy.PrimaryURL,
+				Compliance:  policy.Compliance,
+				Status:      ref.Status,
+				Violations:  ref.Violations,
+
+				Owner:               policy.Owner,
+				RemediationExamples: policy.RemediationExamples,
+				RelatedResources:    policy.RelatedResources,
+				RiskRationale:       policy.RiskRationale,
+			})
+		}
+
+		grouped.Results = append(grouped.Results, GroupedResult{
+			Target:            result.Target,
+			Class:             result.Class,
+			Type:              result.Type,
+			MisconfSummary:    result.MisconfSummary,
+			Misconfigurations: misconfigs,
+		})
+	}
+
+	return grouped, nil
+}
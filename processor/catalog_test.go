@@ -0,0 +1,67 @@
+package processor
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+// loadTestResultInternal은 test-input의 Trivy 결과를 읽습니다.
+func loadTestResultInternal(t *testing.T, name string) *TrivyResult {
+	t.Helper()
+	data, err := os.ReadFile("../test-input/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result TrivyResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatal(err)
+	}
+	return &result
+}
+
+// marshalTestInternal은 값을 JSON 문자열로 직렬화합니다.
+func marshalTestInternal(t *testing.T, v interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestNormalizeRehydrateRoundTrip(t *testing.T) {
+	targetMap := Preprocess(loadTestResultInternal(t, "result-01.json"), nil)
+	catalog := BuildPolicyCatalog(targetMap)
+
+	for _, key := range SortedTargetKeys(targetMap) {
+		original := targetMap[key]
+		normalized := NormalizeResult(original, "../"+PolicyCatalogFilename)
+		if normalized.PolicyCatalog != "../"+PolicyCatalogFilename {
+			t.Errorf("%s: PolicyCatalog = %q", key, normalized.PolicyCatalog)
+		}
+
+		rehydrated, err := RehydrateResult(normalized, catalog)
+		if err != nil {
+			t.Fatalf("%s: %v", key, err)
+		}
+		// 출력 JSON에 포함되지 않는 필드(json:"-")는 복원 대상이 아니므로 JSON으로 비교
+		if got, want := marshalTestInternal(t, rehydrated), marshalTestInternal(t, original); got != want {
+			t.Errorf("%s: rehydrated result differs from original\ngot:  %s\nwant: %s", key, got, want)
+		}
+	}
+}
+
+func TestRehydrateResultUnknownPolicy(t *testing.T) {
+	normalized := &NormalizedTrivyResult{
+		Results: []NormalizedResult{{
+			Target:            "main.tf",
+			Misconfigurations: []PolicyRef{{ID: "AVD-AWS-9999"}},
+		}},
+	}
+	_, err := RehydrateResult(normalized, PolicyCatalog{})
+	if err == nil || !strings.Contains(err.Error(), "AVD-AWS-9999") {
+		t.Fatalf("err = %v, want unknown policy error", err)
+	}
+}
@@ -0,0 +1,199 @@
+package processor
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"trivy-parser/i18n"
+)
+
+// 기본 정책 카테고리
+const (
+	CategoryBuiltin = "builtin" // Trivy 기본 정책 (Namespace가 builtin.으로 시작)
+	CategoryCustom  = "custom"  // 그 외 정책
+)
+
+// CategoryRule은 정책 카테고리 1개의 분류 규칙입니다.
+// Namespaces, IDs는 path.Match 형식 패턴(예: "user.security.*", "PLT-*")이며, 하나라도 일치하면 해당 카테고리로 분류합니다.
+type CategoryRule struct {
+	Name       string   `json:"name"`            // preprocess 파일명 prefix 및 카테고리 키
+	Sheet      string   `json:"sheet,omitempty"` // Excel 시트 이름 (비어 있으면 기본 이름)
+	Namespaces []string `json:"namespaces,omitempty"`
+	IDs        []string `json:"ids,omitempty"`
+}
+
+// ClassifierConfig는 정책 분류 설정 파일 형식입니다.
+//
+//	{
+//	  "categories": [
+//	    {"name": "security", "sheet": "Security", "namespaces": ["user.security.*"]},
+//	    {"name": "platform", "ids": ["PLT-*"]},
+//	    {"name": "builtin", "namespaces": ["builtin.*"]}
+//	  ],
+//	  "default": "custom"
+//	}
+//
+// 규칙은 나열한 순서대로 검사하며 처음 일치한 카테고리를 사용합니다.
+// 카테고리 순서는 Excel 시트 순서이기도 하며, default 카테고리가 목록에 없으면 마지막에 추가됩니다.
+type ClassifierConfig struct {
+	Categories []CategoryRule `json:"categories"`
+	Default    string         `json:"default"`
+}
+
+// Classifier는 정책의 Namespace/ID로 카테고리를 결정합니다.
+type Classifier struct {
+	rules    []CategoryRule
+	fallback string
+}
+
+// categoryNamePattern은 카테고리 이름 형식입니다.
+// 파일명 prefix와 "<카테고리>-<타겟>" 키에 사용되므로 '-'를 허용하지 않습니다.
+var categoryNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.]+$`)
+
+// reservedSheetKeys는 카테고리 시트와 이름이 겹치면 안 되는 고정 시트의 번역 키입니다.
+var reservedSheetKeys = []string{
+	"excel.sheet.priority",
+	"excel.sheet.overdue",
+	"excel.sheet.compliance",
+	"excel.sheet.controls",
+	"excel.sheet.unowned",
+	"excel.sheet.tags",
+	"excel.sheet.modules",
+	"excel.sheet.trend",
+}
+
+// DefaultClassifier는 기존 동작(builtin./그 외)과 같은 분류기를 반환합니다.
+// Excel 시트 순서는 Custom, Built-in입니다.
+func DefaultClassifier() *Classifier {
+	return &Classifier{
+		rules: []CategoryRule{
+			{Name: CategoryCustom},
+			{Name: CategoryBuiltin, Namespaces: []string{"builtin.*"}},
+		},
+		fallback: CategoryCustom,
+	}
+}
+
+// NewClassifier는 설정을 검증하고 분류기를 생성합니다.
+func NewClassifier(config ClassifierConfig) (*Classifier, error) {
+	if config.Default == "" {
+		config.Default = CategoryCustom
+	}
+
+	rules := config.Categories
+	if !containsCategoryInternal(rules, config.Default) {
+		rules = append(rules, CategoryRule{Name: config.Default})
+	}
+
+	seen := make(map[string]bool)
+	for _, rule := range rules {
+		if seen[rule.Name] {
+			return nil, fmt.Errorf("duplicate category: %q", rule.Name)
+		}
+		seen[rule.Name] = true
+
+		if !categoryNamePattern.MatchString(rule.Name) {
+			return nil, fmt.Errorf("invalid category name %q: only letters, digits, '_' and '.' are allowed", rule.Name)
+		}
+		if strings.ContainsAny(rule.Sheet, `[]:*?/\`) || len([]rune(rule.Sheet)) > 31 {
+			return nil, fmt.Errorf("invalid sheet name for category %q: %q", rule.Name, rule.Sheet)
+		}
+		for _, pattern := range append(append([]string{}, rule.Namespaces...), rule.IDs...) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("invalid pattern %q in category %q: %w", pattern, rule.Name, err)
+			}
+		}
+	}
+
+	if err := validateSheetNamesInternal(rules); err != nil {
+		return nil, err
+	}
+
+	return &Classifier{rules: rules, fallback: config.Default}, nil
+}
+
+// validateSheetNamesInternal은 지원하는 모든 언어에서 카테고리 시트 이름이 서로 겹치거나 고정 시트 이름과 겹치지 않는지 확인합니다.
+// Excel은 시트 이름의 대소문자를 구분하지 않습니다.
+func validateSheetNamesInternal(rules []CategoryRule) error {
+	for _, lang := range i18n.Supported() {
+		used := make(map[string]string)
+		for _, key := range reservedSheetKeys {
+			used[strings.ToLower(lang.T(key))] = ""
+		}
+		for _, rule := range rules {
+			sheet := CategorySheetName(rule.Name, rule.Sheet, lang)
+			owner, exists := used[strings.ToLower(sheet)]
+			switch {
+			case exists && owner == "":
+				return fmt.Errorf("sheet name %q of category %q is reserved (language: %s)", sheet, rule.Name, lang)
+			case exists:
+				return fmt.Errorf("duplicate sheet name %q: categories %q and %q (language: %s)", sheet, owner, rule.Name, lang)
+			}
+			used[strings.ToLower(sheet)] = rule.Name
+		}
+	}
+	return nil
+}
+
+// CategorySheetName은 카테고리의 Excel 시트 이름을 반환합니다.
+// 설정된 이름이 없으면 builtin/custom은 번역된 기본 이름, 그 외 카테고리는 카테고리 이름을 사용합니다.
+func CategorySheetName(name, sheet string, l i18n.Lang) string {
+	if sheet != "" {
+		return sheet
+	}
+	switch name {
+	case CategoryBuiltin, CategoryCustom:
+		return l.T("excel.sheet." + name)
+	}
+	return name
+}
+
+// containsCategoryInternal은 규칙 목록에 해당 이름의 카테고리가 있는지 확인합니다.
+func containsCategoryInternal(rules []CategoryRule, name string) bool {
+	for _, rule := range rules {
+		if rule.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Classify는 정책의 카테고리를 반환합니다.
+func (c *Classifier) Classify(namespace, id string) string {
+	for _, rule := range c.rules {
+		if matchAnyInternal(rule.Namespaces, namespace) || matchAnyInternal(rule.IDs, id) {
+			return rule.Name
+		}
+	}
+	return c.fallback
+}
+
+// Categories는 카테고리 이름을 출력 순서대로 반환합니다.
+func (c *Classifier) Categories() []string {
+	names := make([]string, 0, len(c.rules))
+	for _, rule := range c.rules {
+		names = append(names, rule.Name)
+	}
+	return names
+}
+
+// Sheet는 카테고리에 설정된 Excel 시트 이름을 반환합니다 (설정되지 않았으면 빈 문자열).
+func (c *Classifier) Sheet(category string) string {
+	for _, rule := range c.rules {
+		if rule.Name == category {
+			return rule.Sheet
+		}
+	}
+	return ""
+}
+
+// matchAnyInternal은 값이 패턴 중 하나와 일치하는지 확인합니다.
+func matchAnyInternal(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, value); ok {
+			return true
+		}
+	}
+	return false
+}
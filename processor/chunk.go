@@ -0,0 +1,286 @@
+package processor
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Tokenizer는 직렬화된 출력의 토큰 수를 추정합니다.
+type Tokenizer interface {
+	CountTokens(data []byte) int
+}
+
+// ByteTokenizer는 바이트 수를 그대로 토큰 수로 사용합니다 (바이트 예산).
+type ByteTokenizer struct{}
+
+// CountTokens는 바이트 수를 반환합니다.
+func (ByteTokenizer) CountTokens(data []byte) int {
+	return len(data)
+}
+
+// CharRatioTokenizer는 문자 수를 CharsPerToken으로 나눈 값으로 토큰 수를 추정합니다.
+// 영어 기준 약 4자, 한국어가 많으면 더 작은 값을 사용합니다.
+type CharRatioTokenizer struct {
+	CharsPerToken float64
+}
+
+// CountTokens는 문자(rune) 수 기반으로 토큰 수를 추정합니다.
+func (t CharRatioTokenizer) CountTokens(data []byte) int {
+	ratio := t.CharsPerToken
+	if ratio <= 0 {
+		ratio = 4
+	}
+	return int(math.Ceil(float64(utf8.RuneCount(data)) / ratio))
+}
+
+// tokenizers는 이름으로 선택 가능한 토크나이저 목록입니다.
+var tokenizers = map[string]Tokenizer{
+	"bytes":  ByteTokenizer{},
+	"approx": CharRatioTokenizer{CharsPerToken: 4},
+}
+
+// LookupTokenizer는 이름으로 등록된 토크나이저를 조회합니다.
+func LookupTokenizer(name string) (Tokenizer, error) {
+	if tokenizer, ok := tokenizers[strings.ToLower(name)]; ok {
+		return tokenizer, nil
+	}
+
+	names := make([]string, 0, len(tokenizers))
+	for n := range tokenizers {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return nil, fmt.Errorf("unknown tokenizer: %q (supported: %s)", name, strings.Join(names, ", "))
+}
+
+// ChunkOptions는 청크 분할 옵션입니다.
+type ChunkOptions struct {
+	Budget    int       // 청크 1개의 최대 토큰 수
+	Tokenizer Tokenizer // 토큰 수 추정 방식 (nil이면 ByteTokenizer)
+	Pretty    bool      // 실제 출력과 같은 들여쓰기로 크기를 추정
+
+	// Projection이 지정되면 필드 선택 규칙을 적용한 출력 기준으로 크기를 추정
+	Projection *Projection
+}
+
+// chunkUnit은 청크에 담기는 최소 단위(타겟 1개의 정책 1개)입니다.
+type chunkUnit struct {
+	result    GroupedResult // Misconfigurations를 제외한 타겟 정보
+	misconfig GroupedMisconfiguration
+	tokens    int
+}
+
+// ChunkResults는 Preprocess 결과를 토큰 예산 이하의 청크로 묶거나 분할합니다.
+// 카테고리(builtin/custom)별로 따로 묶으며, 키는 "<카테고리>-chunk-0001" 형식입니다.
+// 하나의 GroupedMisconfiguration의 violation은 그 정책 단독으로 예산을 넘는 경우에만 여러 청크로 나눕니다.
+// 여러 청크에 나뉜 정책은 청크마다 심각도에 포함되며, 인덱스 전체 집계에서는 한 번만 셉니다.
+func ChunkResults(targetMap map[string]*GroupedTrivyResult, opts ChunkOptions) (map[string]*GroupedTrivyResult, error) {
+	if opts.Budget <= 0 {
+		return nil, fmt.Errorf("chunk budget must be positive: %d", opts.Budget)
+	}
+	if opts.Tokenizer == nil {
+		opts.Tokenizer = ByteTokenizer{}
+	}
+
+	chunks := make(map[string]*GroupedTrivyResult)
+	if len(targetMap) == 0 {
+		return chunks, nil
+	}
+
+	// 빈 결과의 토큰 수 (청크마다 고정으로 더해지는 비용)
+	var template *GroupedTrivyResult
+	for _, targetResult := range targetMap {
+		template = targetResult
+		break
+	}
+	envelopeTokens, err := estimateTokensInternal(newChunkResultInternal(template), 0, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// 카테고리별로 단위 수집 (타겟 이름 순)
+	var categories []string
+	unitsByCategory := make(map[string][]chunkUnit)
+
+	for _, key := range SortedTargetKeys(targetMap) {
+		targetResult := targetMap[key]
+		category, _ := SplitTargetKey(key, targetResult)
+		if _, exists := unitsByCategory[category]; !exists {
+			categories = append(categories, category)
+		}
+
+		for _, result := range targetResult.Results {
+			header := result
+			header.Misconfigurations = nil
+			headerTokens, err := estimateHeaderTokensInternal(header, opts)
+			if err != nil {
+				return nil, err
+			}
+
+			// 청크 고정 비용과 타겟 헤더를 제외한 나머지가 정책이 사용할 수 있는 예산
+			limit := opts.Budget - envelopeTokens - headerTokens
+			for _, misconfig := range result.Misconfigurations {
+				units, err := splitUnitInternal(header, misconfig, limit, opts)
+				if err != nil {
+					return nil, err
+				}
+				unitsByCategory[category] = append(unitsByCategory[category], units...)
+			}
+		}
+	}
+
+	for _, category := range categories {
+		var current *GroupedTrivyResult
+		currentTokens := 0
+		count := 0
+
+		for _, unit := range unitsByCategory[category] {
+			// 새 타겟이면 헤더 비용 추가
+			headerTokens := 0
+			if current == nil || current.Results[len(current.Results)-1].Target != unit.result.Target {
+				headerTokens, err = estimateHeaderTokensInternal(unit.result, opts)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			// 예산 초과 시 새 청크 시작 (단독으로 예산을 넘는 단위는 자체 청크에 담김)
+			if current == nil || currentTokens+headerTokens+unit.tokens > opts.Budget {
+				count++
+				current = newChunkResultInternal(template)
+				chunks[fmt.Sprintf("%s-chunk-%04d", category, count)] = current
+				currentTokens = envelopeTokens
+				headerTokens, err = estimateHeaderTokensInternal(unit.result, opts)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			if headerTokens > 0 {
+				result := unit.result
+				result.Misconfigurations = []GroupedMisconfiguration{}
+				current.Results = append(current.Results, result)
+			}
+			last := &current.Results[len(current.Results)-1]
+			if n := len(last.Misconfigurations); n > 0 && last.Misconfigurations[n-1].ID == unit.misconfig.ID {
+				// 나뉜 정책의 다음 부분이 같은 청크에 담기면 하나로 합쳐 심각도 집계가 중복되지 않도록 함
+				merged := &last.Misconfigurations[n-1]
+				merged.Violations = append(append([]Violation{}, merged.Violations...), unit.misconfig.Violations...)
+			} else {
+				last.Misconfigurations = append(last.Misconfigurations, unit.misconfig)
+			}
+			last.MisconfSummary.Failures = len(last.Misconfigurations)
+			currentTokens += headerTokens + unit.tokens
+		}
+	}
+
+	for _, chunk := range chunks {
+		calculateSeveritySummaryInternal(chunk)
+	}
+	return chunks, nil
+}
+
+// splitUnitInternal은 정책 하나를 청크 단위로 변환합니다.
+// 정책 단독으로 limit을 넘으면 violation을 나누어 여러 단위로 만듭니다.
+func splitUnitInternal(header GroupedResult, misconfig GroupedMisconfiguration, limit int, opts ChunkOptions) ([]chunkUnit, error) {
+	tokens, err := estimateTokensInternal(misconfig, 4, opts)
+	if err != nil {
+		return nil, err
+	}
+	if tokens+separatorTokensInternal(opts) <= limit || len(misconfig.Violations) <= 1 {
+		return []chunkUnit{{result: header, misconfig: misconfig, tokens: tokens + separatorTokensInternal(opts)}}, nil
+	}
+
+	// violation을 순서대로 채우며 분할 (violation 1개는 더 나누지 않음)
+	var units []chunkUnit
+	part := misconfig
+	part.Violations = nil
+	partTokens := 0
+	for _, violation := range misconfig.Violations {
+		candidate := misconfig
+		candidate.Violations = append(append([]Violation{}, part.Violations...), violation)
+		candidateTokens, err := estimateTokensInternal(candidate, 4, opts)
+		if err != nil {
+			return nil, err
+		}
+		candidateTokens += separatorTokensInternal(opts)
+
+		if len(part.Violations) > 0 && candidateTokens > limit {
+			units = append(units, chunkUnit{result: header, misconfig: part, tokens: partTokens})
+			candidate.Violations = []Violation{violation}
+			if candidateTokens, err = estimateTokensInternal(candidate, 4, opts); err != nil {
+				return nil, err
+			}
+			candidateTokens += separatorTokensInternal(opts)
+		}
+		part, partTokens = candidate, candidateTokens
+	}
+	units = append(units, chunkUnit{result: header, misconfig: part, tokens: partTokens})
+	return units, nil
+}
+
+// estimateHeaderTokensInternal은 청크에 타겟(GroupedResult)을 새로 추가할 때의 고정 비용을 추정합니다.
+// Misconfigurations 배열 자체의 괄호 비용을 포함하기 위해 빈 정책 1개를 넣어 측정한 뒤 그 크기를 뺍니다.
+func estimateHeaderTokensInternal(header GroupedResult, opts ChunkOptions) (int, error) {
+	header.Misconfigurations = []GroupedMisconfiguration{{}}
+	withEmpty, err := estimateTokensInternal(header, 2, opts)
+	if err != nil {
+		return 0, err
+	}
+	empty, err := estimateTokensInternal(GroupedMisconfiguration{}, 4, opts)
+	if err != nil {
+		return 0, err
+	}
+	return withEmpty - empty + separatorTokensInternal(opts), nil
+}
+
+// separatorTokensInternal은 배열 원소 사이 구분자(",\n" + 들여쓰기)의 토큰 수입니다.
+func separatorTokensInternal(opts ChunkOptions) int {
+	if opts.Pretty {
+		return opts.Tokenizer.CountTokens([]byte(",\n" + strings.Repeat("  ", 4)))
+	}
+	return opts.Tokenizer.CountTokens([]byte(","))
+}
+
+// newChunkResultInternal은 원본 스캔 메타데이터만 가진 빈 청크를 생성합니다.
+func newChunkResultInternal(template *GroupedTrivyResult) *GroupedTrivyResult {
+	return &GroupedTrivyResult{
+		SchemaVersion:   template.SchemaVersion,
+		CreatedAt:       template.CreatedAt,
+		ArtifactName:    template.ArtifactName,
+		ArtifactType:    template.ArtifactType,
+		SeveritySummary: &SeveritySummary{},
+		Results:         []GroupedResult{},
+	}
+}
+
+// estimateTokensInternal은 값을 출력 형식대로 직렬화하여 토큰 수를 추정합니다.
+// depth는 출력 JSON에서의 중첩 깊이로, pretty 출력 시 들여쓰기 크기를 맞추는 데 사용합니다.
+func estimateTokensInternal(v interface{}, depth int, opts ChunkOptions) (int, error) {
+	if opts.Projection != nil {
+		switch value := v.(type) {
+		case *GroupedTrivyResult:
+			v = opts.Projection.Apply(value)
+		case GroupedResult:
+			v = opts.Projection.Target(value)
+		case GroupedMisconfiguration:
+			v = opts.Projection.Policy(value)
+		}
+	}
+
+	var data []byte
+	var err error
+	if opts.Pretty {
+		data, err = json.MarshalIndent(v, strings.Repeat("  ", depth), "  ")
+	} else {
+		data, err = json.Marshal(v)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return opts.Tokenizer.CountTokens(data), nil
+}
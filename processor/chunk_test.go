@@ -0,0 +1,159 @@
+package processor
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestChunkResultsPacking(t *testing.T) {
+	targetMap := Preprocess(loadTestResultInternal(t, "result-01.json"), nil)
+	wantViolations := countViolationsInternal(targetMap)
+
+	tests := []struct {
+		budget    int
+		pretty    bool
+		tokenizer Tokenizer
+	}{
+		{budget: 1500},
+		{budget: 4000},
+		{budget: 1 << 20},
+		{budget: 2500, pretty: true},
+		{budget: 800, tokenizer: CharRatioTokenizer{CharsPerToken: 4}},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("budget=%d pretty=%v tokenizer=%T", tt.budget, tt.pretty, tt.tokenizer), func(t *testing.T) {
+			opts := ChunkOptions{Budget: tt.budget, Pretty: tt.pretty, Tokenizer: tt.tokenizer}
+			chunks, err := ChunkResults(targetMap, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if opts.Tokenizer == nil {
+				opts.Tokenizer = ByteTokenizer{}
+			}
+
+			// 카테고리별 키는 0001부터 빠짐없이 이어짐
+			counts := make(map[string]int)
+			for key := range chunks {
+				category, _, _ := strings.Cut(key, "-chunk-")
+				counts[category]++
+			}
+			for category, n := range counts {
+				for i := 1; i <= n; i++ {
+					if _, exists := chunks[fmt.Sprintf("%s-chunk-%04d", category, i)]; !exists {
+						t.Errorf("missing chunk %s-chunk-%04d", category, i)
+					}
+				}
+			}
+
+			for key, chunk := range chunks {
+				// 실제 출력 크기가 예산 이하 (violation 1개짜리 단독 청크는 예외)
+				data, err := marshalChunkInternal(chunk, tt.pretty)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if tokens := opts.Tokenizer.CountTokens(data); tokens > tt.budget && countViolationsInternal(map[string]*GroupedTrivyResult{key: chunk}) > 1 {
+					t.Errorf("%s: %d tokens exceeds budget %d", key, tokens, tt.budget)
+				}
+
+				// 같은 타겟의 같은 정책은 청크 안에서 한 번만 나오고, 심각도는 정책 수와 일치
+				var want SeveritySummary
+				seen := make(map[string]bool)
+				for _, result := range chunk.Results {
+					for _, misconfig := range result.Misconfigurations {
+						id := result.Target + "/" + misconfig.ID
+						if seen[id] {
+							t.Errorf("%s: policy %s appears more than once", key, id)
+						}
+						seen[id] = true
+						want.addSeverity(misconfig.Severity)
+					}
+					if result.MisconfSummary.Failures != len(result.Misconfigurations) {
+						t.Errorf("%s: %s Failures = %d, want %d", key, result.Target, result.MisconfSummary.Failures, len(result.Misconfigurations))
+					}
+				}
+				if *chunk.SeveritySummary != want {
+					t.Errorf("%s: SeveritySummary = %+v, want %+v", key, *chunk.SeveritySummary, want)
+				}
+			}
+
+			if got := countViolationsInternal(chunks); got != wantViolations {
+				t.Errorf("violations = %d, want %d", got, wantViolations)
+			}
+		})
+	}
+}
+
+func TestChunkResultsSplitsLargePolicy(t *testing.T) {
+	misconfig := GroupedMisconfiguration{ID: "AVD-AWS-0001", Severity: "HIGH"}
+	for i := 1; i <= 30; i++ {
+		misconfig.Violations = append(misconfig.Violations, Violation{
+			Resource:  fmt.Sprintf("aws_s3_bucket.bucket_%02d", i),
+			StartLine: i * 10,
+			EndLine:   i*10 + 5,
+			Message:   strings.Repeat("x", 40),
+		})
+	}
+	targetMap := map[string]*GroupedTrivyResult{
+		"builtin-s3.tf": {Results: []GroupedResult{{Target: "s3.tf", Misconfigurations: []GroupedMisconfiguration{misconfig}}}},
+	}
+
+	chunks, err := ChunkResults(targetMap, ChunkOptions{Budget: 1200})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chunks) < 2 {
+		t.Fatalf("chunks = %d, want the policy split across several chunks", len(chunks))
+	}
+
+	// 청크 순서대로 이어 붙이면 원래 violation 순서와 같아야 함
+	var lines []int
+	for i := 1; i <= len(chunks); i++ {
+		chunk := chunks[fmt.Sprintf("builtin-chunk-%04d", i)]
+		if chunk == nil || len(chunk.Results) != 1 || len(chunk.Results[0].Misconfigurations) != 1 {
+			t.Fatalf("chunk %d: unexpected shape", i)
+		}
+		if chunk.SeveritySummary.High != 1 {
+			t.Errorf("chunk %d: High = %d, want 1", i, chunk.SeveritySummary.High)
+		}
+		for _, violation := range chunk.Results[0].Misconfigurations[0].Violations {
+			lines = append(lines, violation.StartLine)
+		}
+	}
+	for i, line := range lines {
+		if line != (i+1)*10 {
+			t.Fatalf("violation order = %v", lines)
+		}
+	}
+	if len(lines) != len(misconfig.Violations) {
+		t.Errorf("violations = %d, want %d", len(lines), len(misconfig.Violations))
+	}
+}
+
+func TestChunkResultsInvalidBudget(t *testing.T) {
+	if _, err := ChunkResults(map[string]*GroupedTrivyResult{}, ChunkOptions{Budget: 0}); err == nil {
+		t.Error("expected error for zero budget")
+	}
+}
+
+// countViolationsInternal은 결과 전체의 violation 수를 셉니다.
+func countViolationsInternal(targetMap map[string]*GroupedTrivyResult) int {
+	count := 0
+	for _, targetResult := range targetMap {
+		for _, result := range targetResult.Results {
+			for _, misconfig := range result.Misconfigurations {
+				count += len(misconfig.Violations)
+			}
+		}
+	}
+	return count
+}
+
+// marshalChunkInternal은 preprocess 출력과 같은 형식으로 청크를 직렬화합니다.
+func marshalChunkInternal(chunk *GroupedTrivyResult, pretty bool) ([]byte, error) {
+	if pretty {
+		return json.MarshalIndent(chunk, "", "  ")
+	}
+	return json.Marshal(chunk)
+}
@@ -0,0 +1,337 @@
+package processor
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Unowned는 CODEOWNERS에서 담당자를 찾지 못한 finding의 담당자 이름입니다.
+const Unowned = "unowned"
+
+// UnownedReportFilename은 preprocess 출력 디렉토리에 저장하는 담당자 없음 리포트 파일 이름입니다.
+const UnownedReportFilename = "unowned.json"
+
+// CodeOwners는 CODEOWNERS 파일(GitHub/GitLab 형식)의 규칙입니다.
+// GitHub 형식은 마지막으로 일치한 규칙의 담당자를 사용하며,
+// GitLab 섹션([Section])이 있으면 섹션마다 마지막으로 일치한 규칙의 담당자를 모두 합칩니다.
+type CodeOwners struct {
+	sections []codeOwnerSectionInternal
+}
+
+type codeOwnerSectionInternal struct {
+	name     string
+	defaults []string // 섹션 기본 담당자 (담당자 없는 규칙에 사용)
+	rules    []codeOwnerRuleInternal
+}
+
+type codeOwnerRuleInternal struct {
+	pattern string
+	owners  []string
+	regexp  *regexp.Regexp
+}
+
+// codeOwnerSectionPattern은 GitLab 섹션 헤더입니다.
+// 예: "[Security]", "^[Optional]", "[Docs][2] @docs-team"
+var codeOwnerSectionPattern = regexp.MustCompile(`^\^?\[([^\]]+)\](?:\[\d+\])?(?:\s+(.*))?$`)
+
+// ParseCodeOwners는 CODEOWNERS 파일 내용을 파싱합니다.
+func ParseCodeOwners(content []byte) (*CodeOwners, error) {
+	owners := &CodeOwners{sections: []codeOwnerSectionInternal{{}}}
+	current := 0
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// GitLab 섹션: 같은 이름(대소문자 무시)의 섹션은 하나로 합침
+		if match := codeOwnerSectionPattern.FindStringSubmatch(line); match != nil {
+			name := strings.TrimSpace(match[1])
+			current = -1
+			for i, section := range owners.sections {
+				if i > 0 && strings.EqualFold(section.name, name) {
+					current = i
+					break
+				}
+			}
+			if current < 0 {
+				owners.sections = append(owners.sections, codeOwnerSectionInternal{name: name})
+				current = len(owners.sections) - 1
+			}
+			if defaults := splitCodeOwnersLineInternal(match[2]); len(defaults) > 0 {
+				owners.sections[current].defaults = defaults
+			}
+			continue
+		}
+
+		fields := splitCodeOwnersLineInternal(line)
+		if len(fields) == 0 {
+			continue
+		}
+		pattern, err := codeOwnerPatternInternal(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("CODEOWNERS line %d: %w", n, err)
+		}
+		section := &owners.sections[current]
+		section.rules = append(section.rules, codeOwnerRuleInternal{
+			pattern: fields[0],
+			owners:  fields[1:],
+			regexp:  pattern,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return owners, nil
+}
+
+// splitCodeOwnersLineInternal은 공백으로 필드를 나눕니다. "\ "는 경로의 공백, "\#"은 '#'으로 처리하며 "#" 이후는 주석입니다.
+func splitCodeOwnersLineInternal(line string) []string {
+	var fields []string
+	var field strings.Builder
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '\\' && i+1 < len(line):
+			i++
+			field.WriteByte(line[i])
+		case c == '#':
+			i = len(line)
+		case c == ' ' || c == '\t':
+			if field.Len() > 0 {
+				fields = append(fields, field.String())
+				field.Reset()
+			}
+		default:
+			field.WriteByte(c)
+		}
+	}
+	if field.Len() > 0 {
+		fields = append(fields, field.String())
+	}
+	return fields
+}
+
+// codeOwnerPatternInternal은 gitignore 형식 패턴을 정규식으로 변환합니다.
+// "/"로 시작하거나 중간에 "/"가 있으면 저장소 루트 기준, 아니면 모든 깊이에서 일치합니다.
+// 마지막 경로가 와일드카드 없는 이름이거나 "/"로 끝나면 디렉터리로 보고 그 아래 모든 파일과 일치하며,
+// "docs/*"처럼 와일드카드로 끝나면 바로 아래 항목과만 일치합니다.
+func codeOwnerPatternInternal(pattern string) (*regexp.Regexp, error) {
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	p := strings.Trim(pattern, "/")
+	last := p[strings.LastIndex(p, "/")+1:]
+	subtree := strings.HasSuffix(pattern, "/") || !strings.ContainsAny(last, "*?")
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+	for i := 0; i < len(p); i++ {
+		switch {
+		case strings.HasPrefix(p[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(p[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case p[i] == '*':
+			b.WriteString("[^/]*")
+		case p[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(p[i : i+1]))
+		}
+	}
+	if subtree {
+		b.WriteString("(?:/.*)?")
+	}
+	b.WriteString("$")
+
+	return regexp.Compile(b.String())
+}
+
+// Owners는 저장소 루트 기준 경로의 담당자 목록을 반환합니다 (없으면 nil).
+func (c *CodeOwners) Owners(target string) []string {
+	target = strings.TrimPrefix(strings.ReplaceAll(target, "\\", "/"), "./")
+
+	var owners []string
+	seen := make(map[string]bool)
+	for _, section := range c.sections {
+		var matched *codeOwnerRuleInternal
+		for i := range section.rules {
+			if section.rules[i].regexp.MatchString(target) {
+				matched = &section.rules[i]
+			}
+		}
+		if matched == nil {
+			continue
+		}
+
+		ruleOwners := matched.owners
+		if len(ruleOwners) == 0 {
+			ruleOwners = section.defaults
+		}
+		for _, owner := range ruleOwners {
+			if !seen[owner] {
+				seen[owner] = true
+				owners = append(owners, owner)
+			}
+		}
+	}
+	return owners
+}
+
+// AssignResults는 Preprocess 결과의 각 Violation에 타겟 경로의 담당자(CodeOwners)를 채웁니다.
+func (c *CodeOwners) AssignResults(targetMap map[string]*GroupedTrivyResult) {
+	for _, targetResult := range targetMap {
+		for r := range targetResult.Results {
+			owners := c.Owners(targetResult.Results[r].Target)
+			misconfigs := targetResult.Results[r].Misconfigurations
+			for i := range misconfigs {
+				for v := range misconfigs[i].Violations {
+					misconfigs[i].Violations[v].CodeOwners = owners
+				}
+			}
+		}
+	}
+}
+
+// AssignExcelData는 Excel 행에 담당자를 채우고 담당자 없는 finding을 집계합니다.
+func (c *CodeOwners) AssignExcelData(data *ExcelData) {
+	data.CodeOwners = true
+	for ci := range data.Categories {
+		rows := data.Categories[ci].Rows
+		for i := range rows {
+			rows[i].CodeOwners = strings.Join(c.Owners(rows[i].Target), " ")
+		}
+	}
+	data.Unowned = SummarizeUnownedExcel(data)
+}
+
+// UnownedReport는 CODEOWNERS에서 담당자를 찾지 못한 타겟 목록입니다.
+type UnownedReport struct {
+	Total           int             `json:"Total"` // 담당자 없는 finding 수
+	SeveritySummary SeveritySummary `json:"SeveritySummary"`
+	Targets         []UnownedTarget `json:"Targets"`
+}
+
+// UnownedTarget은 담당자 없는 타겟 1개의 finding 집계입니다.
+type UnownedTarget struct {
+	Target          string          `json:"Target"`
+	Total           int             `json:"Total"`
+	SeveritySummary SeveritySummary `json:"SeveritySummary"`
+	Policies        []string        `json:"Policies"` // 검출된 정책 ID 목록
+}
+
+// SummarizeUnowned는 Preprocess 결과에서 담당자 없는 finding을 타겟별로 집계합니다.
+// AssignResults 이후에 호출해야 합니다.
+func SummarizeUnowned(targetMap map[string]*GroupedTrivyResult) *UnownedReport {
+	report := &UnownedReport{Targets: []UnownedTarget{}}
+	targets := make(map[string]*UnownedTarget)
+	for _, key := range SortedTargetKeys(targetMap) {
+		for _, result := range targetMap[key].Results {
+			for _, misconfig := range result.Misconfigurations {
+				for _, violation := range misconfig.Violations {
+					if len(violation.CodeOwners) > 0 {
+						continue
+					}
+					report.addInternal(targets, result.Target, misconfig.ID, misconfig.Severity)
+				}
+			}
+		}
+	}
+	report.finishInternal(targets)
+	return report
+}
+
+// SummarizeUnownedExcel은 Excel 행에서 담당자 없는 finding을 타겟별로 집계합니다.
+func SummarizeUnownedExcel(data *ExcelData) *UnownedReport {
+	report := &UnownedReport{Targets: []UnownedTarget{}}
+	targets := make(map[string]*UnownedTarget)
+	for _, category := range data.Categories {
+		for _, row := range category.Rows {
+			if row.CodeOwners == "" {
+				report.addInternal(targets, row.Target, row.PolicyID, row.Severity)
+			}
+		}
+	}
+	report.finishInternal(targets)
+	return report
+}
+
+func (r *UnownedReport) addInternal(targets map[string]*UnownedTarget, target, policyID, severity string) {
+	entry, exists := targets[target]
+	if !exists {
+		entry = &UnownedTarget{Target: target}
+		targets[target] = entry
+	}
+	entry.Total++
+	entry.SeveritySummary.addSeverity(severity)
+	entry.Policies = mergeSortedInternal(entry.Policies, []string{policyID})
+
+	r.Total++
+	r.SeveritySummary.addSeverity(severity)
+}
+
+// finishInternal은 타겟 이름 순으로 목록을 만듭니다.
+func (r *UnownedReport) finishInternal(targets map[string]*UnownedTarget) {
+	for _, entry := range targets {
+		r.Targets = append(r.Targets, *entry)
+	}
+	sort.Slice(r.Targets, func(i, j int) bool {
+		return r.Targets[i].Target < r.Targets[j].Target
+	})
+}
+
+// SplitByOwner는 타겟별 결과를 담당자별 결과로 다시 묶습니다.
+// 키는 "<카테고리>-<담당자>" 형식이며 (예: "custom-org/platform"), 담당자가 여러 명인 타겟은 각 담당자 결과에 모두 포함됩니다.
+// 담당자 없는 타겟은 "<카테고리>-unowned"로 묶입니다.
+func (c *CodeOwners) SplitByOwner(targetMap map[string]*GroupedTrivyResult) map[string]*GroupedTrivyResult {
+	ownerMap := make(map[string]*GroupedTrivyResult)
+	for _, key := range SortedTargetKeys(targetMap) {
+		targetResult := targetMap[key]
+		category, _ := SplitTargetKey(key, targetResult)
+
+		for _, result := range targetResult.Results {
+			owners := c.Owners(result.Target)
+			if len(owners) == 0 {
+				owners = []string{Unowned}
+			}
+
+			for _, owner := range owners {
+				ownerKey := category + "-" + ownerKeyInternal(owner)
+				if _, exists := ownerMap[ownerKey]; !exists {
+					ownerMap[ownerKey] = &GroupedTrivyResult{
+						SchemaVersion: targetResult.SchemaVersion,
+						CreatedAt:     targetResult.CreatedAt,
+						ArtifactName:  targetResult.ArtifactName,
+						ArtifactType:  targetResult.ArtifactType,
+						Owner:         owner,
+						Results:       []GroupedResult{},
+					}
+				}
+				ownerMap[ownerKey].Results = append(ownerMap[ownerKey].Results, result)
+			}
+		}
+	}
+
+	for _, ownerResult := range ownerMap {
+		calculateSeveritySummaryInternal(ownerResult)
+	}
+	return ownerMap
+}
+
+// ownerKeyInternal은 담당자 이름을 파일명에 쓸 수 있는 키로 바꿉니다.
+// 예: "@org/platform" -> "org/platform", "dev@example.com" -> "dev_at_example_com"
+func ownerKeyInternal(owner string) string {
+	owner = strings.TrimPrefix(owner, "@")
+	return strings.NewReplacer("@", "_at_", ".", "_").Replace(owner)
+}
@@ -0,0 +1,147 @@
+package processor
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCodeOwnersPatterns(t *testing.T) {
+	tests := []struct {
+		pattern string
+		target  string
+		want    bool
+	}{
+		// 슬래시가 없으면 모든 깊이에서 일치
+		{pattern: "*.tf", target: "main.tf", want: true},
+		{pattern: "*.tf", target: "modules/vpc/main.tf", want: true},
+		{pattern: "main.tf", target: "modules/vpc/main.tf", want: true},
+		{pattern: "vpc", target: "modules/vpc/main.tf", want: true},
+		{pattern: "*.tf", target: "main.tfvars", want: false},
+
+		// 앞이나 중간에 슬래시가 있으면 루트 기준
+		{pattern: "/main.tf", target: "main.tf", want: true},
+		{pattern: "/main.tf", target: "modules/main.tf", want: false},
+		{pattern: "modules/vpc", target: "modules/vpc/main.tf", want: true},
+		{pattern: "modules/vpc", target: "infra/modules/vpc/main.tf", want: false},
+		{pattern: "vpc/", target: "modules/vpc/main.tf", want: true},
+
+		// 디렉터리와 일치하면 하위 파일 모두 일치
+		{pattern: "/modules/", target: "modules/vpc/nested/main.tf", want: true},
+		{pattern: "modules/*", target: "modules/vpc/main.tf", want: false},
+		{pattern: "docs/*", target: "docs/a.tf", want: true},
+		{pattern: "docs/*", target: "docs/sub/a.tf", want: false},
+		{pattern: "docs/*/", target: "docs/sub/a.tf", want: true},
+		{pattern: "modules/*.tf", target: "modules/vpc/main.tf", want: false},
+
+		// ** 는 0개 이상의 디렉터리
+		{pattern: "**/vpc/*.tf", target: "vpc/main.tf", want: true},
+		{pattern: "**/vpc/*.tf", target: "a/b/vpc/main.tf", want: true},
+		{pattern: "modules/**/main.tf", target: "modules/main.tf", want: true},
+		{pattern: "modules/**/main.tf", target: "modules/a/b/main.tf", want: true},
+		{pattern: "modules/**", target: "modules/a/b/main.tf", want: true},
+		{pattern: "modules/**", target: "other/modules/main.tf", want: false},
+
+		// ? 는 '/'를 제외한 문자 1개, 정규식 메타 문자는 그대로 일치
+		{pattern: "ec?.tf", target: "ec2.tf", want: true},
+		{pattern: "ec?.tf", target: "ec/.tf", want: false},
+		{pattern: "a+b.tf", target: "a+b.tf", want: true},
+		{pattern: "a+b.tf", target: "aab.tf", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.target, func(t *testing.T) {
+			owners, err := ParseCodeOwners([]byte(tt.pattern + " @team"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := len(owners.Owners(tt.target)) > 0; got != tt.want {
+				t.Errorf("pattern %q on %q = %v, want %v", tt.pattern, tt.target, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCodeOwnersOwners(t *testing.T) {
+	content := strings.Join([]string{
+		"# GitHub 형식: 마지막으로 일치한 규칙 사용",
+		"*          @org/default",
+		"*.tf       @org/terraform",
+		"/modules/  @org/platform dev@example.com",
+		"/modules/legacy/",
+		"my\\ dir/  @org/space # 주석",
+		"",
+		"[Security] @org/security",
+		"*.tf",
+		"/iam.tf    @org/iam",
+		"",
+		"^[Docs][2] @org/docs",
+		"*.md",
+		"",
+		"[security]",
+		"/rds.tf    @org/dba",
+	}, "\n")
+	owners, err := ParseCodeOwners([]byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		target string
+		want   []string
+	}{
+		{target: "README", want: []string{"@org/default"}},
+		// 기본 섹션 + Security 섹션 기본 담당자
+		{target: "main.tf", want: []string{"@org/terraform", "@org/security"}},
+		{target: "./ec2.tf", want: []string{"@org/terraform", "@org/security"}},
+		{target: "modules/vpc/main.tf", want: []string{"@org/platform", "dev@example.com", "@org/security"}},
+		// 담당자 없는 규칙은 기본 섹션에서 담당자를 해제
+		{target: "modules/legacy/main.tf", want: []string{"@org/security"}},
+		{target: "my dir/x.tf", want: []string{"@org/space", "@org/security"}},
+		{target: "iam.tf", want: []string{"@org/terraform", "@org/iam"}},
+		// 대소문자만 다른 섹션은 하나로 합쳐져 나중 규칙이 우선
+		{target: "rds.tf", want: []string{"@org/terraform", "@org/dba"}},
+		{target: "docs\\guide.md", want: []string{"@org/default", "@org/docs"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			if got := owners.Owners(tt.target); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Owners(%q) = %v, want %v", tt.target, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCodeOwnersNoMatch(t *testing.T) {
+	owners, err := ParseCodeOwners([]byte("/infra/ @org/infra\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := owners.Owners("app/main.tf"); got != nil {
+		t.Errorf("Owners = %v, want nil", got)
+	}
+}
+
+func TestCodeOwnersSplitByOwner(t *testing.T) {
+	owners, err := ParseCodeOwners([]byte("/modules/ @org/platform dev@example.com\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetMap := map[string]*GroupedTrivyResult{
+		"builtin-modules/vpc/main.tf": {Results: []GroupedResult{{Target: "modules/vpc/main.tf"}}},
+		"custom-main.tf":              {Results: []GroupedResult{{Target: "main.tf"}}},
+	}
+
+	ownerMap := owners.SplitByOwner(targetMap)
+	var keys []string
+	for _, key := range SortedTargetKeys(ownerMap) {
+		keys = append(keys, key+"="+ownerMap[key].Owner)
+	}
+	want := []string{
+		"builtin-dev_at_example_com=dev@example.com",
+		"builtin-org/platform=@org/platform",
+		"custom-unowned=unowned",
+	}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("SplitByOwner keys = %v, want %v", keys, want)
+	}
+}
@@ -0,0 +1,292 @@
+package processor
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//go:embed compliance/*.json
+var builtinCompliance embed.FS
+
+// BuiltinComplianceName은 내장 AWS 매핑을 선택하는 이름입니다.
+const BuiltinComplianceName = "builtin"
+
+// 통제 항목 상태
+const (
+	ControlPass         = "PASS"          // 매핑된 정책이 평가되었고 실패 finding 없음
+	ControlFail         = "FAIL"          // 매핑된 정책의 실패 finding 있음
+	ControlNotEvaluated = "NOT EVALUATED" // 매핑된 정책이 스캔 결과에 없음 (평가 여부를 알 수 없음)
+)
+
+// ComplianceMapping은 정책과 컴플라이언스 프레임워크 통제 항목 간 매핑입니다.
+//
+//	{
+//	  "frameworks": [
+//	    {"id": "cis-aws-1.4", "name": "CIS AWS Foundations v1.4.0", "controls": [{"id": "2.1.1", "title": "..."}]}
+//	  ],
+//	  "mappings": {
+//	    "AVD-AWS-0088": {"cis-aws-1.4": ["2.1.1"]}
+//	  }
+//	}
+//
+// mappings의 키는 정책 ID 또는 AVDID입니다.
+type ComplianceMapping struct {
+	Frameworks []Framework                    `json:"frameworks"`
+	Mappings   map[string]map[string][]string `json:"mappings"` // 정책 ID/AVDID -> 프레임워크 ID -> 통제 항목 ID
+}
+
+// Framework는 컴플라이언스 프레임워크와 통제 항목 목록입니다.
+type Framework struct {
+	ID       string    `json:"id"`
+	Name     string    `json:"name"`
+	Controls []Control `json:"controls"`
+}
+
+// Control은 프레임워크의 통제 항목입니다.
+type Control struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// BuiltinComplianceMapping은 주요 AWS 기본 정책에 대한 내장 매핑(CIS AWS 1.4, PCI DSS 3.2.1, ISMS-P)을 반환합니다.
+func BuiltinComplianceMapping() (*ComplianceMapping, error) {
+	data, err := builtinCompliance.ReadFile("compliance/aws.json")
+	if err != nil {
+		return nil, err
+	}
+
+	mapping := &ComplianceMapping{}
+	if err := json.Unmarshal(data, mapping); err != nil {
+		return nil, fmt.Errorf("builtin compliance mapping: %w", err)
+	}
+	return mapping, nil
+}
+
+// Merge는 다른 매핑을 합칩니다.
+// 같은 ID의 프레임워크는 통제 항목을 합치며 (같은 통제 항목 ID는 나중 값 사용), 정책 매핑은 합집합으로 합칩니다.
+func (m *ComplianceMapping) Merge(other *ComplianceMapping) {
+	for _, framework := range other.Frameworks {
+		existing := m.framework(framework.ID)
+		if existing == nil {
+			m.Frameworks = append(m.Frameworks, framework)
+			continue
+		}
+
+		if framework.Name != "" {
+			existing.Name = framework.Name
+		}
+		for _, control := range framework.Controls {
+			replaced := false
+			for i := range existing.Controls {
+				if existing.Controls[i].ID == control.ID {
+					existing.Controls[i] = control
+					replaced = true
+					break
+				}
+			}
+			if !replaced {
+				existing.Controls = append(existing.Controls, control)
+			}
+		}
+	}
+
+	if m.Mappings == nil {
+		m.Mappings = make(map[string]map[string][]string)
+	}
+	for policyID, frameworks := range other.Mappings {
+		if m.Mappings[policyID] == nil {
+			m.Mappings[policyID] = make(map[string][]string)
+		}
+		for frameworkID, controls := range frameworks {
+			m.Mappings[policyID][frameworkID] = mergeSortedInternal(m.Mappings[policyID][frameworkID], controls)
+		}
+	}
+}
+
+// Validate는 매핑이 정의되지 않은 프레임워크나 통제 항목을 참조하는지 검사합니다.
+func (m *ComplianceMapping) Validate() error {
+	policyIDs := make([]string, 0, len(m.Mappings))
+	for policyID := range m.Mappings {
+		policyIDs = append(policyIDs, policyID)
+	}
+	sort.Strings(policyIDs)
+
+	for _, policyID := range policyIDs {
+		for _, frameworkID := range frameworkIDsInternal(m.Mappings[policyID]) {
+			framework := m.framework(frameworkID)
+			if framework == nil {
+				return fmt.Errorf("policy %q maps to unknown framework %q", policyID, frameworkID)
+			}
+			for _, controlID := range m.Mappings[policyID][frameworkID] {
+				if framework.control(controlID) == nil {
+					return fmt.Errorf("policy %q maps to unknown control %q in framework %q", policyID, controlID, frameworkID)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Controls는 정책 ID 또는 AVDID에 매핑된 통제 항목을 프레임워크별로 반환합니다 (매핑이 없으면 nil).
+func (m *ComplianceMapping) Controls(id, avdID string) map[string][]string {
+	var controls map[string][]string
+	for _, key := range []string{id, avdID} {
+		if key == "" {
+			continue
+		}
+		for frameworkID, controlIDs := range m.Mappings[key] {
+			if controls == nil {
+				controls = make(map[string][]string)
+			}
+			controls[frameworkID] = mergeSortedInternal(controls[frameworkID], controlIDs)
+		}
+	}
+	return controls
+}
+
+// EnrichResults는 Preprocess 결과의 각 정책에 통제 항목 참조(Compliance)를 채웁니다.
+func (m *ComplianceMapping) EnrichResults(targetMap map[string]*GroupedTrivyResult) {
+	for _, targetResult := range targetMap {
+		for r := range targetResult.Results {
+			misconfigs := targetResult.Results[r].Misconfigurations
+			for i := range misconfigs {
+				misconfigs[i].Compliance = m.Controls(misconfigs[i].ID, misconfigs[i].AVDID)
+			}
+		}
+	}
+}
+
+// EnrichExcelData는 Excel 행에 통제 항목 참조를 채우고 프레임워크별 준수 현황을 집계합니다.
+func (m *ComplianceMapping) EnrichExcelData(data *ExcelData) {
+	for c := range data.Categories {
+		rows := data.Categories[c].Rows
+		for i := range rows {
+			rows[i].Compliance = m.Controls(rows[i].PolicyID, rows[i].AVDID)
+		}
+	}
+	data.Compliance = m.Summarize(data)
+}
+
+// FrameworkSummary는 프레임워크 1개의 준수 현황입니다.
+type FrameworkSummary struct {
+	ID           string
+	Name         string
+	Passing      int
+	Failing      int
+	NotEvaluated int
+	Controls     []ControlStatus
+}
+
+// ControlStatus는 통제 항목 1개의 준수 상태입니다.
+type ControlStatus struct {
+	ID       string
+	Title    string
+	Status   string   // PASS, FAIL 또는 NOT EVALUATED
+	Findings int      // 통제 항목에 매핑된 실패 finding 수
+	Policies []string // 실패한 정책 ID 목록
+}
+
+// Summarize는 Excel 행을 기준으로 프레임워크별 통제 항목 준수 현황을 집계합니다.
+// 매핑된 정책의 실패 finding이 하나라도 있으면 FAIL, 매핑된 정책이 실패 없이 평가되었으면 (PASS/EXCEPTION 행) PASS,
+// 매핑된 정책의 행이 없으면 NOT EVALUATED입니다. Trivy는 기본적으로 실패만 출력하므로
+// PASS 판정에는 --include-non-failures로 스캔한 결과가 필요합니다.
+func (m *ComplianceMapping) Summarize(data *ExcelData) []FrameworkSummary {
+	// 프레임워크 -> 통제 항목 -> 집계
+	evaluated := make(map[string]map[string]bool)
+	findings := make(map[string]map[string]int)
+	policies := make(map[string]map[string][]string)
+	for _, category := range data.Categories {
+		for _, row := range category.Rows {
+			for frameworkID, controlIDs := range row.Compliance {
+				if findings[frameworkID] == nil {
+					evaluated[frameworkID] = make(map[string]bool)
+					findings[frameworkID] = make(map[string]int)
+					policies[frameworkID] = make(map[string][]string)
+				}
+				for _, controlID := range controlIDs {
+					evaluated[frameworkID][controlID] = true
+					if !failingStatusInternal(row.Status) {
+						continue
+					}
+					findings[frameworkID][controlID]++
+					policies[frameworkID][controlID] = mergeSortedInternal(policies[frameworkID][controlID], []string{row.PolicyID})
+				}
+			}
+		}
+	}
+
+	summaries := make([]FrameworkSummary, 0, len(m.Frameworks))
+	for _, framework := range m.Frameworks {
+		summary := FrameworkSummary{ID: framework.ID, Name: framework.Name}
+		for _, control := range framework.Controls {
+			status := ControlStatus{
+				ID:       control.ID,
+				Title:    control.Title,
+				Status:   ControlNotEvaluated,
+				Findings: findings[framework.ID][control.ID],
+				Policies: policies[framework.ID][control.ID],
+			}
+			switch {
+			case status.Findings > 0:
+				status.Status = ControlFail
+				summary.Failing++
+			case evaluated[framework.ID][control.ID]:
+				status.Status = ControlPass
+				summary.Passing++
+			default:
+				summary.NotEvaluated++
+			}
+			summary.Controls = append(summary.Controls, status)
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
+
+// failingStatusInternal은 Trivy 평가 결과가 실패인지 확인합니다 (Status가 없는 이전 형식은 실패로 간주).
+func failingStatusInternal(status string) bool {
+	return status == "" || strings.EqualFold(status, "FAIL")
+}
+
+// FormatControls는 통제 항목 참조를 한 줄 텍스트로 만듭니다.
+// 예: "cis-aws-1.4: 2.1.1; pci-dss-3.2.1: 3.4"
+func FormatControls(controls map[string][]string) string {
+	parts := make([]string, 0, len(controls))
+	for _, frameworkID := range frameworkIDsInternal(controls) {
+		parts = append(parts, frameworkID+": "+strings.Join(controls[frameworkID], ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// framework는 ID로 프레임워크를 찾습니다 (없으면 nil).
+func (m *ComplianceMapping) framework(id string) *Framework {
+	for i := range m.Frameworks {
+		if m.Frameworks[i].ID == id {
+			return &m.Frameworks[i]
+		}
+	}
+	return nil
+}
+
+// control은 ID로 통제 항목을 찾습니다 (없으면 nil).
+func (f *Framework) control(id string) *Control {
+	for i := range f.Controls {
+		if f.Controls[i].ID == id {
+			return &f.Controls[i]
+		}
+	}
+	return nil
+}
+
+// frameworkIDsInternal은 통제 항목 참조의 프레임워크 ID를 정렬하여 반환합니다.
+func frameworkIDsInternal(controls map[string][]string) []string {
+	ids := make([]string, 0, len(controls))
+	for id := range controls {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,92 @@
+package processor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComplianceSummarize(t *testing.T) {
+	mapping := &ComplianceMapping{
+		Frameworks: []Framework{
+			{ID: "fw-a", Name: "Framework A", Controls: []Control{{ID: "1", Title: "one"}, {ID: "2", Title: "two"}, {ID: "3", Title: "three"}, {ID: "4", Title: "four"}}},
+			{ID: "fw-b", Name: "Framework B", Controls: []Control{{ID: "x"}, {ID: "y"}}},
+			{ID: "fw-c", Name: "Framework C", Controls: []Control{{ID: "z"}}},
+		},
+		Mappings: map[string]map[string][]string{
+			"P1":    {"fw-a": {"1", "2"}},
+			"P2":    {"fw-a": {"2", "3"}},
+			"P4":    {"fw-a": {"3"}},
+			"AVD-3": {"fw-b": {"x"}},
+			"P5":    {"fw-b": {"x"}},
+		},
+	}
+	if err := mapping.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	data := &ExcelData{Categories: []ExcelCategory{
+		{Name: CategoryBuiltin, Rows: []ExcelRow{
+			{PolicyID: "P1", Resource: "a", Status: "FAIL"},
+			{PolicyID: "P1", Resource: "b", Status: "fail"},
+			{PolicyID: "P2", Status: "PASS"},
+			{PolicyID: "P3", AVDID: "AVD-3"}, // Status가 없는 이전 형식은 실패
+		}},
+		{Name: CategoryCustom, Rows: []ExcelRow{
+			{PolicyID: "P4", Status: "EXCEPTION"},
+			{PolicyID: "P5", Status: "FAIL"},
+			{PolicyID: "P6", Status: "FAIL"}, // 매핑 없음
+		}},
+	}}
+	mapping.EnrichExcelData(data)
+
+	want := []FrameworkSummary{
+		{
+			ID: "fw-a", Name: "Framework A", Passing: 1, Failing: 2, NotEvaluated: 1,
+			Controls: []ControlStatus{
+				{ID: "1", Title: "one", Status: ControlFail, Findings: 2, Policies: []string{"P1"}},
+				{ID: "2", Title: "two", Status: ControlFail, Findings: 2, Policies: []string{"P1"}},
+				{ID: "3", Title: "three", Status: ControlPass},
+				{ID: "4", Title: "four", Status: ControlNotEvaluated},
+			},
+		},
+		{
+			ID: "fw-b", Name: "Framework B", Failing: 1, NotEvaluated: 1,
+			Controls: []ControlStatus{
+				{ID: "x", Status: ControlFail, Findings: 2, Policies: []string{"P3", "P5"}},
+				{ID: "y", Status: ControlNotEvaluated},
+			},
+		},
+		{
+			ID: "fw-c", Name: "Framework C", NotEvaluated: 1,
+			Controls: []ControlStatus{{ID: "z", Status: ControlNotEvaluated}},
+		},
+	}
+	if !reflect.DeepEqual(data.Compliance, want) {
+		t.Errorf("Compliance =\n%+v\nwant\n%+v", data.Compliance, want)
+	}
+	if got := data.Categories[0].Rows[3].Compliance; !reflect.DeepEqual(got, map[string][]string{"fw-b": {"x"}}) {
+		t.Errorf("AVDID mapping = %v", got)
+	}
+	if got := data.Categories[1].Rows[2].Compliance; got != nil {
+		t.Errorf("unmapped policy Compliance = %v, want nil", got)
+	}
+}
+
+func TestComplianceSummarizeEmpty(t *testing.T) {
+	mapping := &ComplianceMapping{Frameworks: []Framework{{ID: "fw", Controls: []Control{{ID: "1"}}}}}
+	got := mapping.Summarize(&ExcelData{})
+	want := []FrameworkSummary{{ID: "fw", NotEvaluated: 1, Controls: []ControlStatus{{ID: "1", Status: ControlNotEvaluated}}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Summarize = %+v, want %+v", got, want)
+	}
+}
+
+func TestBuiltinComplianceMappingValid(t *testing.T) {
+	mapping, err := BuiltinComplianceMapping()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mapping.Validate(); err != nil {
+		t.Error(err)
+	}
+}
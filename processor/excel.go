@@ -4,12 +4,52 @@ import "strings"
 
 // ExcelData는 Excel 파일 생성에 필요한 데이터를 담는 구조체입니다.
 type ExcelData struct {
-	CustomRows  []ExcelRow
-	BuiltinRows []ExcelRow
+	// Categories는 정책 카테고리별 행으로, 카테고리마다 시트 1개를 생성합니다 (분류기 순서).
+	Categories []ExcelCategory
+
+	// Compliance는 -compliance 옵션 사용 시 채워지는 프레임워크별 준수 현황입니다.
+	Compliance []FrameworkSummary
+
+	// Modules는 모듈 안에서 검출된 finding이 있는 경우에만 채워집니다.
+	Modules []ModuleSummary
+
+	// Trend는 여러 스캔을 입력한 경우에만 채워집니다.
+	Trend *TrendData
+
+	// CodeOwners는 -codeowners 옵션으로 담당자를 채운 경우 true이며, Unowned는 담당자 없는 finding 집계입니다.
+	CodeOwners bool
+	Unowned    *UnownedReport
+
+	// TagKeys는 -terraform-root 옵션 사용 시 태그 컬럼 키이며 (nil이면 사용 안 함), TagSummary는 태그 값별 집계입니다.
+	TagKeys     []string
+	TagSummary  []TagSummary
+	RiskWeights bool // -tag-weights 옵션 사용 시 true
+
+	// Priority는 -risk 옵션 사용 시 위험 점수 순 finding 목록입니다 (Priority 시트).
+	Priority *PriorityReport
+
+	// SLA는 -sla 옵션 사용 시 기한 초과 finding 목록입니다 (SLA 컬럼 및 Overdue 시트).
+	SLA *SLAReport
+
+	// Blame은 -blame 옵션으로 커밋 정보를 채운 경우 true입니다.
+	Blame bool
+
+	// PolicyMetadata는 -policy-dir 옵션으로 Rego 메타데이터를 병합한 경우 true입니다.
+	PolicyMetadata bool
+}
+
+// ExcelCategory는 정책 카테고리 1개의 시트 데이터입니다.
+type ExcelCategory struct {
+	Name  string // 카테고리 이름 (예: builtin, custom)
+	Sheet string // 설정된 시트 이름 (비어 있으면 기본 이름)
+	Rows  []ExcelRow
 }
 
 // ExcelRow는 Excel 파일의 한 행을 나타냅니다.
 type ExcelRow struct {
+	PolicyID   string
+	AVDID      string
+	Namespace  string
 	Target     string
 	Title      string
 	Resource   string
@@ -18,19 +58,68 @@ type ExcelRow struct {
 	StartLine  int
 	EndLine    int
 	PrimaryURL string
+	Status     string // Trivy 평가 결과 (FAIL, PASS, EXCEPTION; --include-non-failures 사용 시 FAIL 외 포함)
+
+	// CODEOWNERS 담당자 (공백 구분, -codeowners 옵션 사용 시)
+	CodeOwners string
+
+	// 로컬 .tf에서 찾은 리소스 태그와 모듈 디렉터리, 태그 가중치 (-terraform-root 옵션 사용 시)
+	Tags       map[string]string
+	ModuleDir  string
+	RiskWeight float64
+
+	// 위험 점수 (-risk 옵션 사용 시)
+	Risk *RiskScore
+
+	// 조치 기한 (-sla 옵션 사용 시)
+	SLA *SLAStatus
+
+	// 라인 범위를 마지막으로 수정한 커밋 (-blame 옵션 사용 시)
+	BlameCommit string
+	BlameAuthor string
+	BlameDate   string
+
+	// 모듈 안에서 검출된 경우 호출한 모듈 인스턴스와 루트 파일
+	Module   string
+	RootFile string
+
+	// 상세 컬럼 (-excel-details 옵션 사용 시 출력)
+	Message     string
+	Description string
+	Code        string
+	Occurrences string // 원인 리소스부터 루트 모듈까지의 호출 체인
+
+	// 프레임워크별 통제 항목 참조 (-compliance 옵션 사용 시)
+	Compliance map[string][]string
+
+	// Rego METADATA에서 병합한 정책 정보 (-policy-dir 옵션 사용 시)
+	Owner               string
+	RemediationExamples string
+	RelatedResources    string // 줄바꿈으로 구분
+	RiskRationale       string
+
+	// 스캔 간 finding 식별 및 발견일 (여러 스캔 입력 시 사용)
+	Fingerprint string
+	FirstSeen   string
+	LastSeen    string
 }
 
 // PrepareExcelData는 TrivyResult를 Excel용 데이터로 변환합니다.
-// Custom 정책과 Built-in 정책을 분리하여 반환합니다.
-func PrepareExcelData(data *TrivyResult) *ExcelData {
-	excelData := &ExcelData{
-		CustomRows:  []ExcelRow{},
-		BuiltinRows: []ExcelRow{},
+// 정책 카테고리별로 행을 분리하여 반환합니다 (classifier가 nil이면 DefaultClassifier).
+func PrepareExcelData(data *TrivyResult, classifier *Classifier) *ExcelData {
+	if classifier == nil {
+		classifier = DefaultClassifier()
 	}
+	excelData := newExcelDataInternal(classifier)
 
 	for _, result := range data.Results {
 		for _, misconfig := range result.Misconfigurations {
+			cause := misconfig.CauseMetadata
+			module, rootFile := AttributeModule(cause.Occurrences)
 			row := ExcelRow{
+				PolicyID:   misconfig.ID,
+				AVDID:      misconfig.AVDID,
+				Namespace:  misconfig.Namespace,
 				Target:     result.Target,
 				Title:      misconfig.Title,
 				Resource:   misconfig.CauseMetadata.Resource,
@@ -39,16 +128,48 @@ func PrepareExcelData(data *TrivyResult) *ExcelData {
 				StartLine:  misconfig.CauseMetadata.StartLine,
 				EndLine:    misconfig.CauseMetadata.EndLine,
 				PrimaryURL: misconfig.PrimaryURL,
-			}
+				Status:     misconfig.Status,
+
+				Module:   module,
+				RootFile: rootFile,
 
-			// builtin 정책과 custom 정책 분리
-			if strings.HasPrefix(misconfig.Namespace, "builtin.") {
-				excelData.BuiltinRows = append(excelData.BuiltinRows, row)
-			} else {
-				excelData.CustomRows = append(excelData.CustomRows, row)
+				Message:     misconfig.Message,
+				Description: strings.TrimSpace(misconfig.Description),
+				Code:        FormatCodeSnippet(misconfig.CauseMetadata.Code),
+				Occurrences: FormatOccurrenceChain(cause.Resource, result.Target, cause.StartLine, cause.EndLine, cause.Occurrences),
+
+				Fingerprint: Fingerprint(result.Target, misconfig.ID, misconfig.CauseMetadata.Resource),
 			}
+
+			// 정책 카테고리별로 분리
+			category := excelData.Category(classifier.Classify(misconfig.Namespace, misconfig.ID))
+			category.Rows = append(category.Rows, row)
 		}
 	}
 
+	excelData.Modules = SummarizeModules(excelData)
 	return excelData
 }
+
+// newExcelDataInternal은 분류기의 카테고리마다 빈 시트 데이터를 가진 ExcelData를 생성합니다.
+func newExcelDataInternal(classifier *Classifier) *ExcelData {
+	excelData := &ExcelData{}
+	for _, name := range classifier.Categories() {
+		excelData.Categories = append(excelData.Categories, ExcelCategory{
+			Name:  name,
+			Sheet: classifier.Sheet(name),
+			Rows:  []ExcelRow{},
+		})
+	}
+	return excelData
+}
+
+// Category는 이름으로 카테고리 시트 데이터를 찾습니다 (없으면 nil).
+func (d *ExcelData) Category(name string) *ExcelCategory {
+	for i := range d.Categories {
+		if d.Categories[i].Name == name {
+			return &d.Categories[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package processor
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// FilenameScheme은 preprocess 결과 파일명 생성 방식입니다.
+type FilenameScheme string
+
+const (
+	// FilenameLegacy는 확장자를 제거하고 경로 구분자를 %로 바꾸는 기존 방식입니다 (되돌릴 수 없음).
+	FilenameLegacy FilenameScheme = "legacy"
+	// FilenameEncoded는 DecodeTargetFilename으로 원래 키를 복원할 수 있는 방식입니다.
+	FilenameEncoded FilenameScheme = "encoded"
+	// FilenameTree는 타겟의 디렉토리 구조를 출력 디렉토리 아래에 그대로 재현합니다.
+	FilenameTree FilenameScheme = "tree"
+)
+
+// CollisionPolicy는 서로 다른 타겟이 같은 파일명으로 변환될 때의 처리 방식입니다.
+type CollisionPolicy string
+
+const (
+	// CollisionError는 충돌 시 에러를 반환합니다.
+	CollisionError CollisionPolicy = "error"
+	// CollisionSuffix는 충돌한 파일명에 "-2", "-3" ... 접미사를 붙여 구분합니다.
+	CollisionSuffix CollisionPolicy = "suffix"
+)
+
+// ParseFilenameScheme은 문자열을 FilenameScheme으로 변환합니다.
+func ParseFilenameScheme(s string) (FilenameScheme, error) {
+	switch scheme := FilenameScheme(strings.ToLower(s)); scheme {
+	case FilenameLegacy, FilenameEncoded, FilenameTree:
+		return scheme, nil
+	}
+	return "", fmt.Errorf("unknown filename scheme: %q (supported: legacy, encoded, tree)", s)
+}
+
+// ParseCollisionPolicy는 문자열을 CollisionPolicy로 변환합니다.
+func ParseCollisionPolicy(s string) (CollisionPolicy, error) {
+	switch policy := CollisionPolicy(strings.ToLower(s)); policy {
+	case CollisionError, CollisionSuffix:
+		return policy, nil
+	}
+	return "", fmt.Errorf("unknown collision policy: %q (supported: error, suffix)", s)
+}
+
+// Collision은 파일명 충돌 정보를 담습니다.
+type Collision struct {
+	Key      string // 충돌이 발생한 타겟 키
+	Existing string // 먼저 같은 파일명을 사용한 타겟 키
+	Filename string // 충돌한 파일명
+	Resolved string // 구분된 최종 파일명 (CollisionSuffix인 경우, encoded 방식은 영문자 이스케이프)
+}
+
+// FilenameGenerator는 타겟 키로부터 출력 파일명을 생성하고 충돌을 감지합니다.
+// 대소문자를 구분하지 않는 파일시스템(macOS, Windows)을 고려해 소문자 기준으로 충돌을 판단합니다.
+type FilenameGenerator struct {
+	outputDir   string
+	scheme      FilenameScheme
+	onCollision CollisionPolicy
+	ext         string            // 출력 확장자 (빈 값이면 .json)
+	used        map[string]string // 소문자 파일 경로 -> 타겟 키
+	collisions  []Collision
+}
+
+// NewFilenameGenerator는 FilenameGenerator를 생성합니다.
+func NewFilenameGenerator(outputDir string, scheme FilenameScheme, onCollision CollisionPolicy) *FilenameGenerator {
+	return &FilenameGenerator{
+		outputDir:   outputDir,
+		scheme:      scheme,
+		onCollision: onCollision,
+		used:        make(map[string]string),
+	}
+}
+
+// SetExtension은 생성할 파일의 확장자를 변경합니다 (예: ".md").
+func (g *FilenameGenerator) SetExtension(ext string) {
+	g.ext = ext
+}
+
+// Generate는 타겟 키(예: "builtin-modules/vpc/main.tf")에 대한 출력 파일 경로를 반환합니다.
+func (g *FilenameGenerator) Generate(key string) (string, error) {
+	filename, err := g.filenameInternal(key, false)
+	if err != nil {
+		return "", err
+	}
+
+	existing, exists := g.used[strings.ToLower(filename)]
+	if !exists {
+		g.used[strings.ToLower(filename)] = key
+		return filename, nil
+	}
+
+	if g.onCollision != CollisionSuffix {
+		return "", fmt.Errorf("filename collision: %q and %q both map to %s", existing, key, filename)
+	}
+
+	var resolved string
+	if g.scheme == FilenameEncoded {
+		// encoded 방식은 대소문자만 다른 키끼리 충돌하므로, 번호 대신 영문자까지 ~XX로 이스케이프하여
+		// DecodeTargetFilename으로 복원할 수 있게 구분
+		resolved, _ = g.filenameInternal(key, true)
+		if _, taken := g.used[strings.ToLower(resolved)]; taken {
+			return "", fmt.Errorf("filename collision: %q and %q both map to %s", existing, key, filename)
+		}
+	} else {
+		// 사용되지 않은 접미사를 찾아 구분
+		ext := filepath.Ext(filename)
+		base := strings.TrimSuffix(filename, ext)
+		for n := 2; ; n++ {
+			resolved = fmt.Sprintf("%s-%d%s", base, n, ext)
+			if _, taken := g.used[strings.ToLower(resolved)]; !taken {
+				break
+			}
+		}
+	}
+	g.used[strings.ToLower(resolved)] = key
+	g.collisions = append(g.collisions, Collision{
+		Key:      key,
+		Existing: existing,
+		Filename: filename,
+		Resolved: resolved,
+	})
+	return resolved, nil
+}
+
+// filenameInternal은 파일명 생성 방식과 확장자에 따라 출력 파일 경로를 만듭니다.
+// escapeLetters는 encoded 방식에서 영문자도 ~XX로 이스케이프합니다 (대소문자 충돌 구분용).
+func (g *FilenameGenerator) filenameInternal(key string, escapeLetters bool) (string, error) {
+	var filename string
+	switch g.scheme {
+	case FilenameEncoded:
+		filename = filepath.Join(g.outputDir, encodeTargetFilenameInternal(key, escapeLetters))
+	case FilenameTree:
+		rel, err := treeFilenameInternal(key)
+		if err != nil {
+			return "", err
+		}
+		filename = filepath.Join(g.outputDir, rel)
+	default:
+		filename = GenerateTargetFilename(g.outputDir, key)
+	}
+
+	// 확장자 변경 (예: 렌더링 결과는 .md)
+	if g.ext != "" {
+		filename = strings.TrimSuffix(filename, ".json") + g.ext
+	}
+	return filename, nil
+}
+
+// Collisions는 접미사로 해결된 충돌 목록을 반환합니다.
+func (g *FilenameGenerator) Collisions() []Collision {
+	return g.collisions
+}
+
+// EncodeTargetFilename은 타겟 키를 되돌릴 수 있는 파일명으로 인코딩합니다.
+// 영문자, 숫자, '.', '_', '-'는 그대로 두고, '/'는 "~~", 그 외 바이트는 "~XX"(16진수)로 변환합니다.
+// 확장자는 유지되며 ".json"이 추가됩니다.
+// 예: "builtin-modules/vpc/main.tf" -> "builtin-modules~~vpc~~main.tf.json"
+func EncodeTargetFilename(key string) string {
+	return encodeTargetFilenameInternal(key, false)
+}
+
+// encodeTargetFilenameInternal은 EncodeTargetFilename과 같으며, escapeLetters이면 영문자도 "~XX"로 변환합니다.
+func encodeTargetFilenameInternal(key string, escapeLetters bool) string {
+	var b strings.Builder
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		switch {
+		case c == '/':
+			b.WriteString("~~")
+		case escapeLetters && (c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'):
+			fmt.Fprintf(&b, "~%02X", c)
+		case isSafeFilenameByte(c):
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "~%02X", c)
+		}
+	}
+	b.WriteString(".json")
+	return b.String()
+}
+
+// DecodeTargetFilename은 EncodeTargetFilename으로 생성된 파일명을 원래 타겟 키로 복원합니다.
+// 디렉토리 경로와 마지막 확장자(.json, .md 등)는 제외하고 복원합니다.
+func DecodeTargetFilename(filename string) (string, error) {
+	base := filepath.Base(filename)
+	name := strings.TrimSuffix(base, filepath.Ext(base))
+
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c != '~' {
+			b.WriteByte(c)
+			continue
+		}
+
+		if i+1 < len(name) && name[i+1] == '~' {
+			b.WriteByte('/')
+			i++
+			continue
+		}
+
+		if i+2 >= len(name) {
+			return "", fmt.Errorf("invalid escape at end of %q", filename)
+		}
+		decoded, err := strconv.ParseUint(name[i+1:i+3], 16, 8)
+		if err != nil {
+			return "", fmt.Errorf("invalid escape %q in %q", name[i:i+3], filename)
+		}
+		b.WriteByte(byte(decoded))
+		i += 2
+	}
+	return b.String(), nil
+}
+
+// isSafeFilenameByte는 파일명과 URL에서 그대로 사용할 수 있는 문자인지 확인합니다.
+func isSafeFilenameByte(c byte) bool {
+	return c >= 'a' && c <= 'z' ||
+		c >= 'A' && c <= 'Z' ||
+		c >= '0' && c <= '9' ||
+		c == '.' || c == '_' || c == '-'
+}
+
+// treeFilenameInternal은 타겟 키를 출력 디렉토리 기준 "<카테고리>/<타겟 경로>.json" 상대 경로로 변환합니다.
+// 예: "builtin-modules/vpc/main.tf" -> "builtin/modules/vpc/main.tf.json"
+// 카테고리 디렉토리를 벗어나는 경로("..")는 거부합니다.
+func treeFilenameInternal(key string) (string, error) {
+	category, target, _ := strings.Cut(key, "-")
+	cleaned := path.Clean(category + "/" + strings.ReplaceAll(target, "\\", "/"))
+	if category == "" || category == "." || category == ".." || !strings.HasPrefix(cleaned, category+"/") {
+		return "", fmt.Errorf("target %q escapes the output directory", key)
+	}
+	return filepath.FromSlash(cleaned) + ".json", nil
+}
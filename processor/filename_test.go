@@ -0,0 +1,132 @@
+package processor
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestEncodeDecodeTargetFilename(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{key: "builtin-main.tf", want: "builtin-main.tf.json"},
+		{key: "builtin-modules/vpc/main.tf", want: "builtin-modules~~vpc~~main.tf.json"},
+		{key: "custom-dir with space/a%b.tf", want: "custom-dir~20with~20space~~a~25b.tf.json"},
+		{key: "custom-~tilde/x.tf", want: "custom-~7Etilde~~x.tf.json"},
+		{key: "builtin-한글/main.tf", want: "builtin-~ED~95~9C~EA~B8~80~~main.tf.json"},
+		{key: "builtin-C:\\infra\\main.tf", want: "builtin-C~3A~5Cinfra~5Cmain.tf.json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			encoded := EncodeTargetFilename(tt.key)
+			if encoded != tt.want {
+				t.Errorf("EncodeTargetFilename(%q) = %q, want %q", tt.key, encoded, tt.want)
+			}
+			decoded, err := DecodeTargetFilename(filepath.Join("out", encoded))
+			if err != nil {
+				t.Fatalf("DecodeTargetFilename(%q): %v", encoded, err)
+			}
+			if decoded != tt.key {
+				t.Errorf("DecodeTargetFilename(%q) = %q, want %q", encoded, decoded, tt.key)
+			}
+
+			// 대소문자 충돌 구분용으로 영문자까지 이스케이프해도 같은 키로 복원되어야 함
+			escaped := encodeTargetFilenameInternal(tt.key, true)
+			decoded, err = DecodeTargetFilename(escaped)
+			if err != nil || decoded != tt.key {
+				t.Errorf("DecodeTargetFilename(%q) = %q, %v, want %q", escaped, decoded, err, tt.key)
+			}
+		})
+	}
+}
+
+func TestDecodeTargetFilenameInvalid(t *testing.T) {
+	for _, filename := range []string{"builtin-a~.json", "builtin-a~4.json", "builtin-~ZZ.json"} {
+		if _, err := DecodeTargetFilename(filename); err == nil {
+			t.Errorf("DecodeTargetFilename(%q): expected error", filename)
+		}
+	}
+}
+
+func TestFilenameGenerator(t *testing.T) {
+	tests := []struct {
+		name        string
+		scheme      FilenameScheme
+		onCollision CollisionPolicy
+		keys        []string
+		want        []string // 빈 값이면 에러 기대
+	}{
+		{
+			name:   "legacy",
+			scheme: FilenameLegacy,
+			keys:   []string{"builtin-main.tf", "builtin-modules/vpc/main.tf"},
+			want:   []string{"builtin-main.json", "builtin-modules%vpc%main.json"},
+		},
+		{
+			name:        "legacy collision error",
+			scheme:      FilenameLegacy,
+			onCollision: CollisionError,
+			keys:        []string{"builtin-main.tf", "builtin-main.yaml"},
+			want:        []string{"builtin-main.json", ""},
+		},
+		{
+			name:        "legacy collision suffix",
+			scheme:      FilenameLegacy,
+			onCollision: CollisionSuffix,
+			keys:        []string{"builtin-main.tf", "builtin-main.yaml", "builtin-MAIN.tf"},
+			want:        []string{"builtin-main.json", "builtin-main-2.json", "builtin-MAIN-3.json"},
+		},
+		{
+			name:        "encoded case collision suffix",
+			scheme:      FilenameEncoded,
+			onCollision: CollisionSuffix,
+			keys:        []string{"builtin-EC2.tf", "builtin-ec2.tf"},
+			want:        []string{"builtin-EC2.tf.json", "~62~75~69~6C~74~69~6E-~65~632.~74~66.json"},
+		},
+		{
+			name:        "encoded case collision error",
+			scheme:      FilenameEncoded,
+			onCollision: CollisionError,
+			keys:        []string{"builtin-EC2.tf", "builtin-ec2.tf"},
+			want:        []string{"builtin-EC2.tf.json", ""},
+		},
+		{
+			name:   "tree",
+			scheme: FilenameTree,
+			keys:   []string{"builtin-main.tf", "builtin-modules/vpc/main.tf", "custom-modules/vpc/main.tf"},
+			want:   []string{"builtin/main.tf.json", "builtin/modules/vpc/main.tf.json", "custom/modules/vpc/main.tf.json"},
+		},
+		{
+			name:   "tree escape",
+			scheme: FilenameTree,
+			keys:   []string{"builtin-../custom/main.tf", "builtin-/etc/passwd"},
+			want:   []string{"", "builtin/etc/passwd.json"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewFilenameGenerator("out", tt.scheme, tt.onCollision)
+			for i, key := range tt.keys {
+				got, err := g.Generate(key)
+				if tt.want[i] == "" {
+					if err == nil {
+						t.Errorf("Generate(%q) = %q, expected error", key, got)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("Generate(%q): %v", key, err)
+				}
+				if want := filepath.Join("out", filepath.FromSlash(tt.want[i])); got != want {
+					t.Errorf("Generate(%q) = %q, want %q", key, got, want)
+				}
+				if tt.scheme == FilenameEncoded {
+					if decoded, err := DecodeTargetFilename(got); err != nil || decoded != key {
+						t.Errorf("DecodeTargetFilename(%q) = %q, %v, want %q", got, decoded, err, key)
+					}
+				}
+			}
+		})
+	}
+}
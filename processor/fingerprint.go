@@ -0,0 +1,13 @@
+package processor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Fingerprint는 스캔 간에 동일한 finding을 식별하기 위한 지문을 생성합니다.
+// 라인 번호는 코드 수정으로 쉽게 바뀌므로 제외하고 타겟, 정책 ID, 리소스만 사용합니다.
+func Fingerprint(target, policyID, resource string) string {
+	sum := sha256.Sum256([]byte(target + "\x00" + policyID + "\x00" + resource))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,283 @@
+package processor
+
+import (
+	"sort"
+	"time"
+)
+
+// HistoryScan은 이력 저장소에 저장된 스캔 1회분의 메타데이터입니다.
+type HistoryScan struct {
+	ID           int64
+	ArtifactName string
+	ArtifactType string
+	CreatedAt    string
+}
+
+// HistoryObservation은 스캔 1회에서 관측한 finding 1개입니다.
+type HistoryObservation struct {
+	ScanID      int64
+	Fingerprint string
+	Category    string
+	Target      string
+	PolicyID    string
+	Severity    string
+	Title       string
+	Resource    string
+}
+
+// 이력 조회 종류
+const (
+	HistoryQueryTrend     = "trend"
+	HistoryQueryFirstSeen = "first-seen"
+	HistoryQueryTimeToFix = "time-to-fix"
+	HistoryQueryOpen      = "open"
+)
+
+// FindingLifecycle은 아티팩트 1개에서 finding 1개의 최초/최종 발견 및 조치 이력입니다.
+// 중간 스캔에서 사라졌다가 다시 나타나면 같은 finding으로 보며, 조치일은 마지막 발견 이후 첫 스캔입니다.
+type FindingLifecycle struct {
+	ArtifactName string `json:"ArtifactName"`
+	Fingerprint  string `json:"Fingerprint"`
+	Category     string `json:"Category"`
+	Target       string `json:"Target"`
+	PolicyID     string `json:"PolicyID"`
+	Severity     string `json:"Severity"`
+	Title        string `json:"Title"`
+	Resource     string `json:"Resource"`
+	FirstSeen    string `json:"FirstSeen"`
+	LastSeen     string `json:"LastSeen"`
+	FixedAt      string `json:"FixedAt,omitempty"` // 조치 확인 스캔 (열려 있으면 비어 있음)
+	Open         bool   `json:"Open"`              // 아티팩트의 최신 스캔에 남아 있는지 여부
+	Scans        int    `json:"Scans"`             // 발견된 스캔 수
+	Days         int    `json:"Days"`              // 열려 있으면 최초 발견~최신 스캔, 조치됐으면 최초 발견~조치일 (일)
+}
+
+// BuildLifecycles는 스캔별 관측 결과로 finding 이력을 만듭니다.
+// 스캔은 아티팩트별로 CreatedAt 순으로 처리하며, 결과는 아티팩트, 타겟, 정책 ID, 지문 순입니다.
+func BuildLifecycles(scans []HistoryScan, observations []HistoryObservation) []FindingLifecycle {
+	byScan := make(map[int64][]HistoryObservation)
+	for _, observation := range observations {
+		byScan[observation.ScanID] = append(byScan[observation.ScanID], observation)
+	}
+
+	type lifecycleKey struct{ artifact, fingerprint string }
+	lifecycles := make(map[lifecycleKey]*FindingLifecycle)
+	latest := make(map[string]string) // 아티팩트 -> 최신 스캔 CreatedAt
+
+	for _, scan := range sortHistoryScansInternal(scans) {
+		latest[scan.ArtifactName] = scan.CreatedAt
+		seen := make(map[string]bool)
+		for _, observation := range byScan[scan.ID] {
+			if seen[observation.Fingerprint] {
+				continue
+			}
+			seen[observation.Fingerprint] = true
+
+			key := lifecycleKey{scan.ArtifactName, observation.Fingerprint}
+			lifecycle, exists := lifecycles[key]
+			if !exists {
+				lifecycle = &FindingLifecycle{
+					ArtifactName: scan.ArtifactName,
+					Fingerprint:  observation.Fingerprint,
+					FirstSeen:    scan.CreatedAt,
+				}
+				lifecycles[key] = lifecycle
+			}
+			// 정책 정보는 가장 최근 관측 값을 사용
+			lifecycle.Category = observation.Category
+			lifecycle.Target = observation.Target
+			lifecycle.PolicyID = observation.PolicyID
+			lifecycle.Severity = observation.Severity
+			lifecycle.Title = observation.Title
+			lifecycle.Resource = observation.Resource
+			lifecycle.LastSeen = scan.CreatedAt
+			lifecycle.FixedAt = ""
+			lifecycle.Scans++
+		}
+
+		// 이전 스캔까지 열려 있다가 이번 스캔에서 사라진 finding은 조치된 것으로 기록
+		for key, lifecycle := range lifecycles {
+			if key.artifact == scan.ArtifactName && !seen[key.fingerprint] && lifecycle.FixedAt == "" {
+				lifecycle.FixedAt = scan.CreatedAt
+			}
+		}
+	}
+
+	result := make([]FindingLifecycle, 0, len(lifecycles))
+	for _, lifecycle := range lifecycles {
+		lifecycle.Open = lifecycle.LastSeen == latest[lifecycle.ArtifactName]
+		end := lifecycle.FixedAt
+		if lifecycle.Open {
+			end = lifecycle.LastSeen
+		}
+		lifecycle.Days = daysBetweenInternal(lifecycle.FirstSeen, end)
+		result = append(result, *lifecycle)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.ArtifactName != b.ArtifactName {
+			return a.ArtifactName < b.ArtifactName
+		}
+		if a.Target != b.Target {
+			return a.Target < b.Target
+		}
+		if a.PolicyID != b.PolicyID {
+			return a.PolicyID < b.PolicyID
+		}
+		return a.Fingerprint < b.Fingerprint
+	})
+	return result
+}
+
+// HistoryTrend는 스캔별 관측 결과를 심각도/카테고리별로 집계합니다 (CreatedAt 순).
+func HistoryTrend(scans []HistoryScan, observations []HistoryObservation) *TrendData {
+	byScan := make(map[int64][]HistoryObservation)
+	for _, observation := range observations {
+		byScan[observation.ScanID] = append(byScan[observation.ScanID], observation)
+	}
+
+	trend := &TrendData{Points: []TrendPoint{}}
+	for _, scan := range sortHistoryScansInternal(scans) {
+		point := TrendPoint{
+			CreatedAt:    scan.CreatedAt,
+			ArtifactName: scan.ArtifactName,
+			Categories:   make(map[string]int),
+		}
+		for _, observation := range byScan[scan.ID] {
+			point.Severity.addSeverity(observation.Severity)
+			point.Categories[observation.Category]++
+			point.Total++
+		}
+		trend.Points = append(trend.Points, point)
+	}
+	return trend
+}
+
+// sortHistoryScansInternal은 스캔을 CreatedAt 순으로 정렬한 복사본을 반환합니다.
+func sortHistoryScansInternal(scans []HistoryScan) []HistoryScan {
+	sorted := make([]HistoryScan, len(scans))
+	copy(sorted, scans)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ti, errI := ParseCreatedAt(sorted[i].CreatedAt)
+		tj, errJ := ParseCreatedAt(sorted[j].CreatedAt)
+		if errI != nil || errJ != nil {
+			return sorted[i].CreatedAt < sorted[j].CreatedAt
+		}
+		return ti.Before(tj)
+	})
+	return sorted
+}
+
+// daysBetweenInternal은 두 CreatedAt 사이의 일수를 반환합니다 (파싱할 수 없으면 0).
+func daysBetweenInternal(from, to string) int {
+	start, err := ParseCreatedAt(from)
+	if err != nil {
+		return 0
+	}
+	end, err := ParseCreatedAt(to)
+	if err != nil || end.Before(start) {
+		return 0
+	}
+	return int(end.Sub(start).Hours() / 24)
+}
+
+// OpenLifecycles는 아티팩트의 최신 스캔에 남아 있는 finding만 반환합니다.
+func OpenLifecycles(lifecycles []FindingLifecycle) []FindingLifecycle {
+	open := []FindingLifecycle{}
+	for _, lifecycle := range lifecycles {
+		if lifecycle.Open {
+			open = append(open, lifecycle)
+		}
+	}
+	return open
+}
+
+// TimeToFixSummary는 심각도별 조치 소요 일수 집계입니다.
+type TimeToFixSummary struct {
+	Severity   string  `json:"Severity"`
+	Fixed      int     `json:"Fixed"` // 조치된 finding 수
+	Open       int     `json:"Open"`  // 아직 열려 있는 finding 수
+	MeanDays   float64 `json:"MeanDays"`
+	MedianDays float64 `json:"MedianDays"`
+	MaxDays    int     `json:"MaxDays"`
+}
+
+// SummarizeTimeToFix는 finding 이력을 심각도별 조치 소요 일수로 집계합니다 (CRITICAL, HIGH, MEDIUM, LOW, UNKNOWN 순).
+func SummarizeTimeToFix(lifecycles []FindingLifecycle) []TimeToFixSummary {
+	days := make(map[string][]int)
+	open := make(map[string]int)
+	for _, lifecycle := range lifecycles {
+		severity := severityLabelInternal(lifecycle.Severity)
+		if lifecycle.Open {
+			open[severity]++
+			continue
+		}
+		if lifecycle.FixedAt != "" {
+			days[severity] = append(days[severity], lifecycle.Days)
+		}
+	}
+
+	var summaries []TimeToFixSummary
+	for _, severity := range []string{"CRITICAL", "HIGH", "MEDIUM", "LOW", "UNKNOWN"} {
+		values := days[severity]
+		if len(values) == 0 && open[severity] == 0 {
+			continue
+		}
+		summary := TimeToFixSummary{Severity: severity, Fixed: len(values), Open: open[severity]}
+		if len(values) > 0 {
+			sort.Ints(values)
+			total := 0
+			for _, value := range values {
+				total += value
+			}
+			summary.MeanDays = roundScoreInternal(float64(total) / float64(len(values)))
+			summary.MaxDays = values[len(values)-1]
+			if mid := len(values) / 2; len(values)%2 == 1 {
+				summary.MedianDays = float64(values[mid])
+			} else {
+				summary.MedianDays = float64(values[mid-1]+values[mid]) / 2
+			}
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
+
+// severityLabelInternal은 집계용 심각도 이름을 반환합니다 (알 수 없는 값은 UNKNOWN).
+func severityLabelInternal(severity string) string {
+	switch rank := SeverityRank(severity); rank {
+	case 0:
+		return "CRITICAL"
+	case 1:
+		return "HIGH"
+	case 2:
+		return "MEDIUM"
+	case 3:
+		return "LOW"
+	}
+	return "UNKNOWN"
+}
+
+// LifecycleHistory는 finding 이력에서 지문별 최초 발견 시각을 구합니다 (-history-db).
+func LifecycleHistory(lifecycles []FindingLifecycle) FindingHistory {
+	history := make(FindingHistory)
+	for _, lifecycle := range lifecycles {
+		if first, err := ParseCreatedAt(lifecycle.FirstSeen); err == nil {
+			history.addInternal(lifecycle.Fingerprint, first)
+		}
+	}
+	return history
+}
+
+// Merge는 다른 이력을 합칩니다 (같은 지문은 더 이른 시각 사용).
+func (h FindingHistory) Merge(other FindingHistory) {
+	for fingerprint, first := range other {
+		h.addInternal(fingerprint, first)
+	}
+}
+
+func (h FindingHistory) addInternal(fingerprint string, first time.Time) {
+	if existing, exists := h[fingerprint]; !exists || first.Before(existing) {
+		h[fingerprint] = first
+	}
+}
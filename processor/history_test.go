@@ -0,0 +1,132 @@
+package processor
+
+import (
+	"reflect"
+	"testing"
+)
+
+// historyFixtureInternal은 아티팩트 a의 스캔 4회(순서를 섞어 저장)와 아티팩트 b의 스캔 1회입니다.
+//
+//	f1: 1~4회 모두 발견 (같은 스캔에 중복 관측 포함)
+//	f2: 1회만 발견 -> 2회에서 조치
+//	f3: 1회 발견, 2회 사라짐, 3회 재발견, 4회 사라짐 -> 조치일은 4회
+//	f4: 2회 발견, 3회 사라짐, 4회 재발견 -> 열림
+func historyFixtureInternal() ([]HistoryScan, []HistoryObservation) {
+	scans := []HistoryScan{
+		{ID: 3, ArtifactName: "a", CreatedAt: "2026-01-21T00:00:00Z"},
+		{ID: 1, ArtifactName: "a", CreatedAt: "2026-01-01T00:00:00Z"},
+		{ID: 5, ArtifactName: "b", CreatedAt: "2026-01-05T00:00:00Z"},
+		{ID: 4, ArtifactName: "a", CreatedAt: "2026-02-01T00:00:00+09:00"},
+		{ID: 2, ArtifactName: "a", CreatedAt: "2026-01-11T00:00:00Z"},
+	}
+	observe := func(scanID int64, fingerprint, severity, category string) HistoryObservation {
+		return HistoryObservation{ScanID: scanID, Fingerprint: fingerprint, Category: category, Target: fingerprint + ".tf", PolicyID: "P-" + fingerprint, Severity: severity}
+	}
+	observations := []HistoryObservation{
+		observe(1, "f1", "HIGH", "builtin"), observe(1, "f1", "HIGH", "builtin"),
+		observe(1, "f2", "LOW", "custom"), observe(1, "f3", "MEDIUM", "builtin"),
+		observe(2, "f1", "HIGH", "builtin"), observe(2, "f4", "CRITICAL", "custom"),
+		observe(3, "f1", "HIGH", "builtin"), observe(3, "f3", "MEDIUM", "builtin"),
+		observe(4, "f1", "CRITICAL", "builtin"), observe(4, "f4", "CRITICAL", "custom"),
+		observe(5, "f1", "LOW", "builtin"),
+	}
+	return scans, observations
+}
+
+func TestBuildLifecycles(t *testing.T) {
+	scans, observations := historyFixtureInternal()
+	lifecycles := BuildLifecycles(scans, observations)
+
+	type summary struct {
+		artifact, fingerprint, severity string
+		firstSeen, lastSeen, fixedAt    string
+		open                            bool
+		scans, days                     int
+	}
+	want := []summary{
+		{"a", "f1", "CRITICAL", "2026-01-01T00:00:00Z", "2026-02-01T00:00:00+09:00", "", true, 4, 30},
+		{"a", "f2", "LOW", "2026-01-01T00:00:00Z", "2026-01-01T00:00:00Z", "2026-01-11T00:00:00Z", false, 1, 10},
+		{"a", "f3", "MEDIUM", "2026-01-01T00:00:00Z", "2026-01-21T00:00:00Z", "2026-02-01T00:00:00+09:00", false, 2, 30},
+		{"a", "f4", "CRITICAL", "2026-01-11T00:00:00Z", "2026-02-01T00:00:00+09:00", "", true, 2, 20},
+		{"b", "f1", "LOW", "2026-01-05T00:00:00Z", "2026-01-05T00:00:00Z", "", true, 1, 0},
+	}
+	var got []summary
+	for _, l := range lifecycles {
+		got = append(got, summary{l.ArtifactName, l.Fingerprint, l.Severity, l.FirstSeen, l.LastSeen, l.FixedAt, l.Open, l.Scans, l.Days})
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("BuildLifecycles =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if open := OpenLifecycles(lifecycles); len(open) != 3 {
+		t.Errorf("OpenLifecycles = %d, want 3", len(open))
+	}
+	history := LifecycleHistory(lifecycles)
+	if first := history["f1"].UTC().Format("2006-01-02"); first != "2026-01-01" {
+		t.Errorf("LifecycleHistory[f1] = %s, want earliest first seen across artifacts", first)
+	}
+}
+
+func TestSummarizeTimeToFix(t *testing.T) {
+	fixed := func(severity string, days int) FindingLifecycle {
+		return FindingLifecycle{Severity: severity, FixedAt: "2026-01-01T00:00:00Z", Days: days}
+	}
+	tests := []struct {
+		name       string
+		lifecycles []FindingLifecycle
+		want       []TimeToFixSummary
+	}{
+		{
+			name:       "odd count median",
+			lifecycles: []FindingLifecycle{fixed("HIGH", 3), fixed("high", 1), fixed("HIGH", 8)},
+			want:       []TimeToFixSummary{{Severity: "HIGH", Fixed: 3, MeanDays: 4, MedianDays: 3, MaxDays: 8}},
+		},
+		{
+			name:       "even count median",
+			lifecycles: []FindingLifecycle{fixed("MEDIUM", 10), fixed("MEDIUM", 1), fixed("MEDIUM", 4), fixed("MEDIUM", 2)},
+			want:       []TimeToFixSummary{{Severity: "MEDIUM", Fixed: 4, MeanDays: 4.25, MedianDays: 3, MaxDays: 10}},
+		},
+		{
+			name: "open only and unknown severity in severity order",
+			lifecycles: []FindingLifecycle{
+				fixed("weird", 5),
+				{Severity: "LOW", Open: true, Days: 40},
+				fixed("CRITICAL", 1), fixed("CRITICAL", 2),
+				{Severity: "LOW"}, // 열리지도 조치되지도 않은 항목은 제외
+			},
+			want: []TimeToFixSummary{
+				{Severity: "CRITICAL", Fixed: 2, MeanDays: 1.5, MedianDays: 1.5, MaxDays: 2},
+				{Severity: "LOW", Open: 1},
+				{Severity: "UNKNOWN", Fixed: 1, MeanDays: 5, MedianDays: 5, MaxDays: 5},
+			},
+		},
+		{
+			name:       "repeating mean rounded",
+			lifecycles: []FindingLifecycle{fixed("LOW", 1), fixed("LOW", 1), fixed("LOW", 2)},
+			want:       []TimeToFixSummary{{Severity: "LOW", Fixed: 3, MeanDays: 1.33, MedianDays: 1, MaxDays: 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SummarizeTimeToFix(tt.lifecycles); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SummarizeTimeToFix = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHistoryTrend(t *testing.T) {
+	scans, observations := historyFixtureInternal()
+	trend := HistoryTrend(scans, observations)
+
+	want := []TrendPoint{
+		{CreatedAt: "2026-01-01T00:00:00Z", ArtifactName: "a", Severity: SeveritySummary{High: 2, Medium: 1, Low: 1}, Categories: map[string]int{"builtin": 3, "custom": 1}, Total: 4},
+		{CreatedAt: "2026-01-05T00:00:00Z", ArtifactName: "b", Severity: SeveritySummary{Low: 1}, Categories: map[string]int{"builtin": 1}, Total: 1},
+		{CreatedAt: "2026-01-11T00:00:00Z", ArtifactName: "a", Severity: SeveritySummary{Critical: 1, High: 1}, Categories: map[string]int{"builtin": 1, "custom": 1}, Total: 2},
+		{CreatedAt: "2026-01-21T00:00:00Z", ArtifactName: "a", Severity: SeveritySummary{High: 1, Medium: 1}, Categories: map[string]int{"builtin": 2}, Total: 2},
+		{CreatedAt: "2026-02-01T00:00:00+09:00", ArtifactName: "a", Severity: SeveritySummary{Critical: 2}, Categories: map[string]int{"builtin": 1, "custom": 1}, Total: 2},
+	}
+	if !reflect.DeepEqual(trend.Points, want) {
+		t.Errorf("HistoryTrend =\n%+v\nwant\n%+v", trend.Points, want)
+	}
+}
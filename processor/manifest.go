@@ -0,0 +1,176 @@
+package processor
+
+import (
+	"sort"
+	"strings"
+)
+
+// ManifestFilename은 preprocess 출력 디렉토리에 생성되는 인덱스 파일 이름입니다.
+const ManifestFilename = "index.json"
+
+// Manifest는 preprocess로 생성된 모든 파일을 설명하는 인덱스입니다.
+// 소비자가 개별 파일을 열지 않고도 필요한 파일을 고를 수 있도록 합니다.
+type Manifest struct {
+	SchemaVersion int             `json:"SchemaVersion"`
+	CreatedAt     string          `json:"CreatedAt"`
+	ArtifactName  string          `json:"ArtifactName"`
+	ArtifactType  string          `json:"ArtifactType"`
+	Summary       ManifestSummary `json:"Summary"`
+	Files         []ManifestFile  `json:"Files"`
+
+	// 전체 집계에 포함한 카테고리+타겟+정책 (청크로 나뉘거나 담당자별로 중복된 정책을 한 번만 집계)
+	counted map[string]bool
+	// TargetCount 집계에 포함한 타겟
+	targets map[string]bool
+}
+
+// ManifestFile은 생성된 파일 1개에 대한 정보입니다.
+type ManifestFile struct {
+	Filename        string          `json:"Filename"` // 출력 디렉토리 기준 상대 경로 ('/' 구분자)
+	Target          string          `json:"Target"`
+	Category        string          `json:"Category"`
+	SeveritySummary SeveritySummary `json:"SeveritySummary"`
+	PolicyIDs       []string        `json:"PolicyIDs"`
+	Size            int64           `json:"Size"`
+	SHA256          string          `json:"SHA256"`
+}
+
+// ManifestSummary는 전체 파일에 대한 집계입니다.
+type ManifestSummary struct {
+	FileCount       int                         `json:"FileCount"`
+	TargetCount     int                         `json:"TargetCount"`
+	TotalSize       int64                       `json:"TotalSize"`
+	SeveritySummary SeveritySummary             `json:"SeveritySummary"`
+	Categories      map[string]*CategorySummary `json:"Categories"`
+	PolicyIDs       []string                    `json:"PolicyIDs"`
+}
+
+// CategorySummary는 정책 카테고리(기본: builtin/custom)별 집계입니다.
+type CategorySummary struct {
+	FileCount       int             `json:"FileCount"`
+	SeveritySummary SeveritySummary `json:"SeveritySummary"`
+}
+
+// NewManifest는 원본 스캔 메타데이터로 빈 Manifest를 생성합니다.
+func NewManifest(input *TrivyResult) *Manifest {
+	return &Manifest{
+		SchemaVersion: input.SchemaVersion,
+		CreatedAt:     input.CreatedAt,
+		ArtifactName:  input.ArtifactName,
+		ArtifactType:  input.ArtifactType,
+		Summary: ManifestSummary{
+			Categories: make(map[string]*CategorySummary),
+			PolicyIDs:  []string{},
+		},
+		Files:   []ManifestFile{},
+		counted: make(map[string]bool),
+		targets: make(map[string]bool),
+	}
+}
+
+// SplitTargetKey는 Preprocess 결과의 키를 카테고리와 타겟으로 분리합니다.
+// 예: "builtin-modules/vpc/main.tf" -> ("builtin", "modules/vpc/main.tf")
+func SplitTargetKey(key string, result *GroupedTrivyResult) (category, target string) {
+	if len(result.Results) > 0 {
+		target = result.Results[0].Target
+		if strings.HasSuffix(key, "-"+target) {
+			return strings.TrimSuffix(key, "-"+target), target
+		}
+	}
+
+	category, target, _ = strings.Cut(key, "-")
+	return category, target
+}
+
+// AddFile은 생성된 파일 정보를 Manifest에 추가하고 전체 집계를 갱신합니다.
+func (m *Manifest) AddFile(key, filename string, result *GroupedTrivyResult, size int64, checksum string) {
+	category, target := SplitTargetKey(key, result)
+
+	summary := SeveritySummary{}
+	if result.SeveritySummary != nil {
+		summary = *result.SeveritySummary
+	}
+
+	file := ManifestFile{
+		Filename:        filename,
+		Target:          target,
+		Category:        category,
+		SeveritySummary: summary,
+		PolicyIDs:       policyIDsInternal(result),
+		Size:            size,
+		SHA256:          checksum,
+	}
+	m.Files = append(m.Files, file)
+
+	// 전체 집계 갱신
+	m.Summary.FileCount++
+	m.Summary.TotalSize += size
+
+	// 전체/카테고리 심각도는 여러 파일에 나뉜 같은 정책을 한 번만 집계
+	var distinct SeveritySummary
+	for _, res := range result.Results {
+		for _, misconfig := range res.Misconfigurations {
+			policyKey := category + "\x00" + res.Target + "\x00" + misconfig.ID
+			if !m.counted[policyKey] {
+				m.counted[policyKey] = true
+				distinct.addSeverity(misconfig.Severity)
+			}
+		}
+	}
+	m.Summary.SeveritySummary.add(distinct)
+
+	categorySummary, exists := m.Summary.Categories[category]
+	if !exists {
+		categorySummary = &CategorySummary{}
+		m.Summary.Categories[category] = categorySummary
+	}
+	categorySummary.FileCount++
+	categorySummary.SeveritySummary.add(distinct)
+
+	m.Summary.PolicyIDs = mergeSortedInternal(m.Summary.PolicyIDs, file.PolicyIDs)
+
+	if !m.targets[target] {
+		m.targets[target] = true
+		m.Summary.TargetCount++
+	}
+}
+
+// add는 다른 SeveritySummary의 카운트를 더합니다.
+func (s *SeveritySummary) add(other SeveritySummary) {
+	s.Critical += other.Critical
+	s.High += other.High
+	s.Medium += other.Medium
+	s.Low += other.Low
+}
+
+// policyIDsInternal은 결과에 포함된 정책 ID를 중복 없이 정렬하여 반환합니다.
+func policyIDsInternal(result *GroupedTrivyResult) []string {
+	seen := make(map[string]bool)
+	ids := []string{}
+	for _, res := range result.Results {
+		for _, misconfig := range res.Misconfigurations {
+			if !seen[misconfig.ID] {
+				seen[misconfig.ID] = true
+				ids = append(ids, misconfig.ID)
+			}
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// mergeSortedInternal은 두 정렬된 문자열 목록을 중복 없이 병합합니다.
+func mergeSortedInternal(a, b []string) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	merged := make([]string, 0, len(a)+len(b))
+	for _, list := range [][]string{a, b} {
+		for _, s := range list {
+			if !seen[s] {
+				seen[s] = true
+				merged = append(merged, s)
+			}
+		}
+	}
+	sort.Strings(merged)
+	return merged
+}
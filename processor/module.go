@@ -0,0 +1,121 @@
+package processor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// RootModule은 모듈 밖(루트 모듈)에서 검출된 finding의 모듈 이름입니다.
+const RootModule = "root"
+
+// AttributeModule은 Occurrences에서 finding을 호출한 모듈 인스턴스와 루트 파일을 찾습니다.
+// Occurrences는 안쪽 블록부터 바깥쪽 호출 순서이므로, 바깥쪽부터 module.* 항목을 이어 붙입니다.
+// 예: [aws_s3_bucket.this, module.logs, module.app (main.tf)] -> ("module.app.module.logs", "main.tf")
+// 모듈 밖의 finding이면 빈 문자열을 반환합니다.
+func AttributeModule(occurrences []Occurrence) (module, rootFile string) {
+	var path []string
+	for i := len(occurrences) - 1; i >= 0; i-- {
+		occurrence := occurrences[i]
+		if !strings.HasPrefix(occurrence.Resource, "module.") {
+			continue
+		}
+		if rootFile == "" {
+			rootFile = occurrence.Filename
+		}
+		path = append(path, occurrence.Resource)
+	}
+	return strings.Join(path, "."), rootFile
+}
+
+// ModuleName은 finding의 모듈 이름을 반환합니다 (모듈 밖이면 RootModule).
+func ModuleName(module string) string {
+	if module == "" {
+		return RootModule
+	}
+	return module
+}
+
+// FormatOccurrenceChain은 원인 리소스부터 루트 모듈까지의 호출 체인을 한 줄 텍스트로 만듭니다.
+// 예: "aws_s3_bucket.this (modules/s3/main.tf:3-10) <- module.s3 (main.tf:1-5)"
+func FormatOccurrenceChain(resource, target string, startLine, endLine int, occurrences []Occurrence) string {
+	chain := []string{formatOccurrenceInternal(resource, target, startLine, endLine)}
+	for _, occurrence := range occurrences {
+		chain = append(chain, formatOccurrenceInternal(occurrence.Resource, occurrence.Filename,
+			occurrence.Location.StartLine, occurrence.Location.EndLine))
+	}
+	return strings.Join(chain, " <- ")
+}
+
+func formatOccurrenceInternal(resource, filename string, startLine, endLine int) string {
+	if startLine == endLine {
+		return fmt.Sprintf("%s (%s:%d)", resource, filename, startLine)
+	}
+	return fmt.Sprintf("%s (%s:%d-%d)", resource, filename, startLine, endLine)
+}
+
+// ModuleSummary는 모듈 인스턴스 1개에 대한 finding 집계입니다.
+type ModuleSummary struct {
+	Module     string
+	RootFile   string
+	Targets    []string // 모듈 내에서 finding이 검출된 파일 목록
+	Severity   SeveritySummary
+	Categories map[string]int // 정책 카테고리별 검출 수
+	Total      int
+}
+
+// SummarizeModules는 Excel 행을 모듈 인스턴스별로 집계합니다.
+// 모듈 안의 finding이 하나도 없으면 nil을 반환합니다.
+// 루트 모듈이 맨 앞에 오고, 나머지는 모듈 이름 순입니다.
+func SummarizeModules(data *ExcelData) []ModuleSummary {
+	summaries := make(map[string]*ModuleSummary)
+	targets := make(map[string]map[string]bool)
+	hasModule := false
+
+	for _, category := range data.Categories {
+		for _, row := range category.Rows {
+			name := ModuleName(row.Module)
+			if row.Module != "" {
+				hasModule = true
+			}
+
+			summary, exists := summaries[name]
+			if !exists {
+				summary = &ModuleSummary{Module: name, RootFile: row.RootFile, Categories: make(map[string]int)}
+				summaries[name] = summary
+				targets[name] = make(map[string]bool)
+			}
+			targets[name][row.Target] = true
+
+			summary.Severity.addSeverity(row.Severity)
+			summary.Categories[category.Name]++
+			summary.Total++
+		}
+	}
+
+	if !hasModule {
+		return nil
+	}
+
+	names := make([]string, 0, len(summaries))
+	for name := range summaries {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if (names[i] == RootModule) != (names[j] == RootModule) {
+			return names[i] == RootModule
+		}
+		return names[i] < names[j]
+	})
+
+	result := make([]ModuleSummary, 0, len(names))
+	for _, name := range names {
+		summary := summaries[name]
+		for target := range targets[name] {
+			summary.Targets = append(summary.Targets, target)
+		}
+		sort.Strings(summary.Targets)
+		result = append(result, *summary)
+	}
+	return result
+}
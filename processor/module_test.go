@@ -0,0 +1,54 @@
+package processor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewViolationOccurrences(t *testing.T) {
+	occurrence := func(resource, filename string, start, end int) Occurrence {
+		return Occurrence{Resource: resource, Filename: filename, Location: Location{StartLine: start, EndLine: end}}
+	}
+	moduleChain := []Occurrence{
+		occurrence("aws_s3_bucket.this", "modules/s3/main.tf", 1, 12),
+		occurrence("module.logs", "modules/app/main.tf", 4, 8),
+		occurrence("module.app", "main.tf", 1, 5),
+	}
+	rootChain := []Occurrence{
+		occurrence("metadata_options", "ec2.tf", 7, 10),
+		occurrence("aws_instance.web", "ec2.tf", 2, 20),
+	}
+
+	tests := []struct {
+		name            string
+		occurrences     []Occurrence
+		wantModule      string
+		wantRootFile    string
+		wantOccurrences []Occurrence
+	}{
+		{name: "module finding keeps the chain", occurrences: moduleChain, wantModule: "module.app.module.logs", wantRootFile: "main.tf", wantOccurrences: moduleChain},
+		{name: "root module finding drops the block chain", occurrences: rootChain},
+		{name: "no occurrences"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			misconf := Misconfiguration{Message: "m", CauseMetadata: CauseMetadata{Resource: "r", StartLine: 3, EndLine: 4, Occurrences: tt.occurrences}}
+			violation := newViolationInternal(misconf)
+			if violation.Module != tt.wantModule || violation.RootFile != tt.wantRootFile {
+				t.Errorf("Module, RootFile = %q, %q, want %q, %q", violation.Module, violation.RootFile, tt.wantModule, tt.wantRootFile)
+			}
+			if !reflect.DeepEqual(violation.Occurrences, tt.wantOccurrences) {
+				t.Errorf("Occurrences = %+v, want %+v", violation.Occurrences, tt.wantOccurrences)
+			}
+		})
+	}
+}
+
+func TestFormatOccurrenceChain(t *testing.T) {
+	got := FormatOccurrenceChain("aws_s3_bucket.this", "modules/s3/main.tf", 3, 3, []Occurrence{
+		{Resource: "module.s3", Filename: "main.tf", Location: Location{StartLine: 1, EndLine: 5}},
+	})
+	if want := "aws_s3_bucket.this (modules/s3/main.tf:3) <- module.s3 (main.tf:1-5)"; got != want {
+		t.Errorf("FormatOccurrenceChain = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,120 @@
+package processor
+
+import (
+	"sort"
+	"time"
+)
+
+// NotifyOptions는 채팅 알림 요약 옵션입니다.
+type NotifyOptions struct {
+	Top  int    // 요약에 포함할 신규 finding 최대 개수
+	Link string // 결과물 링크 (예: CI 아티팩트 URL, 비어 있으면 생략)
+}
+
+// NotifySummary는 채팅 알림에 사용하는 스캔 요약입니다 (알림 템플릿의 데이터).
+type NotifySummary struct {
+	ArtifactName string
+	CreatedAt    string
+	Total        int
+	Severity     SeveritySummary
+	Categories   []NotifyCategory // 분류기 카테고리 순서 (검출 없는 카테고리 포함)
+
+	// 이번 스캔 이전의 이력(-baseline, -history-db, Excel 이전 입력 스캔)이 있으면 true. 없으면 모든 finding을 신규로 간주
+	HasHistory bool
+	New        int
+	NewSummary SeveritySummary
+	TopNew     []NotifyFinding // 심각도 높은 순 상위 Top개
+
+	Link string
+}
+
+// NotifyCategory는 정책 카테고리별 검출 수입니다.
+type NotifyCategory struct {
+	Name     string
+	Total    int
+	Severity SeveritySummary
+}
+
+// NotifyFinding은 알림에 표시하는 finding 1개입니다.
+type NotifyFinding struct {
+	Category  string
+	Severity  string
+	PolicyID  string
+	Title     string
+	Target    string
+	Resource  string
+	StartLine int
+	EndLine   int
+}
+
+// SummarizeNotification은 스캔 결과를 심각도/카테고리별로 집계하고 신규 finding 상위 목록을 만듭니다.
+// history에서 최초 발견 시각이 이번 스캔(scannedAt) 이후인 finding을 신규로 봅니다 (classifier가 nil이면 DefaultClassifier).
+func SummarizeNotification(data *TrivyResult, classifier *Classifier, history FindingHistory, scannedAt time.Time, options NotifyOptions) *NotifySummary {
+	if classifier == nil {
+		classifier = DefaultClassifier()
+	}
+	summary := &NotifySummary{
+		ArtifactName: data.ArtifactName,
+		CreatedAt:    data.CreatedAt,
+		Link:         options.Link,
+	}
+	for _, first := range history {
+		if first.Before(scannedAt) {
+			summary.HasHistory = true
+			break
+		}
+	}
+	categoryIndex := make(map[string]int)
+	for _, name := range classifier.Categories() {
+		categoryIndex[name] = len(summary.Categories)
+		summary.Categories = append(summary.Categories, NotifyCategory{Name: name})
+	}
+
+	var newFindings []NotifyFinding
+	for _, result := range data.Results {
+		for _, misconfig := range result.Misconfigurations {
+			cause := misconfig.CauseMetadata
+			category := &summary.Categories[categoryIndex[classifier.Classify(misconfig.Namespace, misconfig.ID)]]
+			summary.Total++
+			summary.Severity.addSeverity(misconfig.Severity)
+			category.Total++
+			category.Severity.addSeverity(misconfig.Severity)
+
+			if first, exists := history[Fingerprint(result.Target, misconfig.ID, cause.Resource)]; exists && first.Before(scannedAt) {
+				continue
+			}
+			summary.New++
+			summary.NewSummary.addSeverity(misconfig.Severity)
+			newFindings = append(newFindings, NotifyFinding{
+				Category:  category.Name,
+				Severity:  misconfig.Severity,
+				PolicyID:  misconfig.ID,
+				Title:     misconfig.Title,
+				Target:    result.Target,
+				Resource:  cause.Resource,
+				StartLine: cause.StartLine,
+				EndLine:   cause.EndLine,
+			})
+		}
+	}
+
+	// 심각도 높은 순, 같으면 타겟/정책/라인 순으로 정렬하여 실행마다 같은 목록 보장
+	sort.SliceStable(newFindings, func(i, j int) bool {
+		a, b := newFindings[i], newFindings[j]
+		if rankA, rankB := SeverityRank(a.Severity), SeverityRank(b.Severity); rankA != rankB {
+			return rankA < rankB
+		}
+		if a.Target != b.Target {
+			return a.Target < b.Target
+		}
+		if a.PolicyID != b.PolicyID {
+			return a.PolicyID < b.PolicyID
+		}
+		return a.StartLine < b.StartLine
+	})
+	if options.Top >= 0 && len(newFindings) > options.Top {
+		newFindings = newFindings[:options.Top]
+	}
+	summary.TopNew = newFindings
+	return summary
+}
@@ -8,14 +8,19 @@ import (
 // Preprocess는 Trivy 스캔 결과를 그룹화하고 타겟별로 분리하는 전처리를 수행합니다.
 // 1. 동일한 정책 ID의 misconfiguration들을 그룹화
 // 2. 타겟(.tf 파일)별로 분리
-// 3. Trivy 기본 정책(builtin-)과 커스텀 정책(custom-)으로 구분
+// 3. 정책 카테고리별로 구분 (기본: Trivy 기본 정책 builtin-, 커스텀 정책 custom-)
 // 4. 각 타겟별로 심각도 요약 계산
-func Preprocess(input *TrivyResult) map[string]*GroupedTrivyResult {
+// classifier가 nil이면 DefaultClassifier를 사용합니다.
+func Preprocess(input *TrivyResult, classifier *Classifier) map[string]*GroupedTrivyResult {
+	if classifier == nil {
+		classifier = DefaultClassifier()
+	}
+
 	// 1단계: 정책별 그룹화
 	grouped := groupByPolicyInternal(input)
 
-	// 2단계: 타겟별 분리 및 정책 유형별 분류
-	targetMap := splitByTargetInternal(grouped)
+	// 2단계: 타겟별 분리 및 정책 카테고리별 분류
+	targetMap := splitByTargetInternal(grouped, classifier)
 
 	return targetMap
 }
@@ -31,8 +36,9 @@ func groupByPolicyInternal(input *TrivyResult) *GroupedTrivyResult {
 	}
 
 	for i, result := range input.Results {
-		// 정책 ID별로 그룹화하기 위한 맵
+		// 정책 ID별로 그룹화하기 위한 맵 (출력 순서는 최초 등장 순서를 따름)
 		policyMap := make(map[string]*GroupedMisconfiguration)
+		var policyOrder []string
 
 		for _, misconf := range result.Misconfigurations {
 			// 정책 ID를 키로 사용
@@ -40,16 +46,10 @@ func groupByPolicyInternal(input *TrivyResult) *GroupedTrivyResult {
 
 			if existing, exists := policyMap[policyKey]; exists {
 				// 이미 존재하는 정책에 violation 추가
-				existing.Violations = append(existing.Violations, Violation{
-					Resource:  misconf.CauseMetadata.Resource,
-					Provider:  misconf.CauseMetadata.Provider,
-					Service:   misconf.CauseMetadata.Service,
-					StartLine: misconf.CauseMetadata.StartLine,
-					EndLine:   misconf.CauseMetadata.EndLine,
-					Message:   misconf.Message,
-				})
+				existing.Violations = append(existing.Violations, newViolationInternal(misconf))
 			} else {
 				// 새로운 정책 추가
+				policyOrder = append(policyOrder, policyKey)
 				policyMap[policyKey] = &GroupedMisconfiguration{
 					ID:          misconf.ID,
 					Title:       misconf.Title,
@@ -59,24 +59,19 @@ func groupByPolicyInternal(input *TrivyResult) *GroupedTrivyResult {
 					Severity:    misconf.Severity,
 					PrimaryURL:  misconf.PrimaryURL,
 					Status:      misconf.Status,
-					Violations: []Violation{
-						{
-							Resource:  misconf.CauseMetadata.Resource,
-							Provider:  misconf.CauseMetadata.Provider,
-							Service:   misconf.CauseMetadata.Service,
-							StartLine: misconf.CauseMetadata.StartLine,
-							EndLine:   misconf.CauseMetadata.EndLine,
-							Message:   misconf.Message,
-						},
-					},
+					Type:        misconf.Type,
+					AVDID:       misconf.AVDID,
+					Query:       misconf.Query,
+					References:  misconf.References,
+					Violations:  []Violation{newViolationInternal(misconf)},
 				}
 			}
 		}
 
-		// 맵을 슬라이스로 변환
+		// 맵을 슬라이스로 변환 (맵 순회 순서에 의존하지 않도록 등장 순서 사용)
 		groupedMisconfs := make([]GroupedMisconfiguration, 0, len(policyMap))
-		for _, policy := range policyMap {
-			groupedMisconfs = append(groupedMisconfs, *policy)
+		for _, policyKey := range policyOrder {
+			groupedMisconfs = append(groupedMisconfs, *policyMap[policyKey])
 		}
 
 		// GroupedResult 생성
@@ -97,8 +92,30 @@ func groupByPolicyInternal(input *TrivyResult) *GroupedTrivyResult {
 	return grouped
 }
 
-// splitByTargetInternal은 그룹화된 결과를 타겟별로 분리하고, Trivy 기본 정책과 커스텀 정책으로 구분합니다.
-func splitByTargetInternal(input *GroupedTrivyResult) map[string]*GroupedTrivyResult {
+// newViolationInternal은 misconfiguration 1건을 Violation으로 변환합니다.
+func newViolationInternal(misconf Misconfiguration) Violation {
+	module, rootFile := AttributeModule(misconf.CauseMetadata.Occurrences)
+	violation := Violation{
+		Resource:  misconf.CauseMetadata.Resource,
+		Provider:  misconf.CauseMetadata.Provider,
+		Service:   misconf.CauseMetadata.Service,
+		StartLine: misconf.CauseMetadata.StartLine,
+		EndLine:   misconf.CauseMetadata.EndLine,
+		Message:   misconf.Message,
+		Module:    module,
+		RootFile:  rootFile,
+		Code:      misconf.CauseMetadata.Code,
+	}
+	// 호출 체인은 모듈 안의 finding에만 기록 (루트 모듈 finding은 블록 체인뿐이라 생략)
+	if module != "" {
+		violation.Occurrences = misconf.CauseMetadata.Occurrences
+	}
+	return violation
+}
+
+// splitByTargetInternal은 그룹화된 결과를 타겟별로 분리하고, 정책 카테고리별로 구분합니다.
+// 키는 "<카테고리>-<타겟>" 형식입니다 (예: "builtin-main.tf").
+func splitByTargetInternal(input *GroupedTrivyResult, classifier *Classifier) map[string]*GroupedTrivyResult {
 	targetMap := make(map[string]*GroupedTrivyResult)
 
 	for _, result := range input.Results {
@@ -112,43 +129,23 @@ func splitByTargetInternal(input *GroupedTrivyResult) map[string]*GroupedTrivyRe
 			continue
 		}
 
-		// Trivy 기본 정책과 커스텀 정책으로 Misconfiguration 분리
-		trivyMisconfigs := []GroupedMisconfiguration{}
-		customMisconfigs := []GroupedMisconfiguration{}
-
+		// 카테고리별로 Misconfiguration 분리
+		categoryMisconfigs := make(map[string][]GroupedMisconfiguration)
 		for _, misconfig := range result.Misconfigurations {
-			if isBuiltinPolicyInternal(misconfig) {
-				trivyMisconfigs = append(trivyMisconfigs, misconfig)
-			} else {
-				customMisconfigs = append(customMisconfigs, misconfig)
-			}
+			category := classifier.Classify(misconfig.Namespace, misconfig.ID)
+			categoryMisconfigs[category] = append(categoryMisconfigs[category], misconfig)
 		}
 
-		// Trivy 기본 정책 결과 저장 (builtin- prefix)
-		if len(trivyMisconfigs) > 0 {
-			trivyKey := "builtin-" + result.Target
-			if _, exists := targetMap[trivyKey]; !exists {
-				targetMap[trivyKey] = &GroupedTrivyResult{
-					SchemaVersion:   input.SchemaVersion,
-					CreatedAt:       input.CreatedAt,
-					ArtifactName:    input.ArtifactName,
-					ArtifactType:    input.ArtifactType,
-					SeveritySummary: &SeveritySummary{},
-					Results:         []GroupedResult{},
-				}
+		// 카테고리별 결과 저장 (<카테고리>- prefix)
+		for _, category := range classifier.Categories() {
+			misconfigs := categoryMisconfigs[category]
+			if len(misconfigs) == 0 {
+				continue
 			}
 
-			trivyResult := result
-			trivyResult.Misconfigurations = trivyMisconfigs
-			trivyResult.MisconfSummary.Failures = len(trivyMisconfigs)
-			targetMap[trivyKey].Results = append(targetMap[trivyKey].Results, trivyResult)
-		}
-
-		// 커스텀 정책 결과 저장 (custom- prefix)
-		if len(customMisconfigs) > 0 {
-			customKey := "custom-" + result.Target
-			if _, exists := targetMap[customKey]; !exists {
-				targetMap[customKey] = &GroupedTrivyResult{
+			key := category + "-" + result.Target
+			if _, exists := targetMap[key]; !exists {
+				targetMap[key] = &GroupedTrivyResult{
 					SchemaVersion:   input.SchemaVersion,
 					CreatedAt:       input.CreatedAt,
 					ArtifactName:    input.ArtifactName,
@@ -158,10 +155,10 @@ func splitByTargetInternal(input *GroupedTrivyResult) map[string]*GroupedTrivyRe
 				}
 			}
 
-			customResult := result
-			customResult.Misconfigurations = customMisconfigs
-			customResult.MisconfSummary.Failures = len(customMisconfigs)
-			targetMap[customKey].Results = append(targetMap[customKey].Results, customResult)
+			categoryResult := result
+			categoryResult.Misconfigurations = misconfigs
+			categoryResult.MisconfSummary.Failures = len(misconfigs)
+			targetMap[key].Results = append(targetMap[key].Results, categoryResult)
 		}
 	}
 
@@ -173,27 +170,13 @@ func splitByTargetInternal(input *GroupedTrivyResult) map[string]*GroupedTrivyRe
 	return targetMap
 }
 
-// isBuiltinPolicyInternal은 해당 정책이 Trivy 기본 정책인지 확인합니다.
-func isBuiltinPolicyInternal(misconfig GroupedMisconfiguration) bool {
-	return strings.HasPrefix(misconfig.Namespace, "builtin.")
-}
-
 // calculateSeveritySummaryInternal은 GroupedTrivyResult의 심각도별 카운트를 계산합니다.
 func calculateSeveritySummaryInternal(result *GroupedTrivyResult) {
 	summary := &SeveritySummary{}
 
 	for _, res := range result.Results {
 		for _, misconfig := range res.Misconfigurations {
-			switch strings.ToUpper(misconfig.Severity) {
-			case "CRITICAL":
-				summary.Critical++
-			case "HIGH":
-				summary.High++
-			case "MEDIUM":
-				summary.Medium++
-			case "LOW":
-				summary.Low++
-			}
+			summary.addSeverity(misconfig.Severity)
 		}
 	}
 
@@ -0,0 +1,251 @@
+package processor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// 필드 선택 레벨
+const (
+	LevelResult    = "result"    // GroupedTrivyResult
+	LevelTarget    = "target"    // GroupedResult
+	LevelPolicy    = "policy"    // GroupedMisconfiguration
+	LevelViolation = "violation" // Violation
+)
+
+// fieldDef는 출력 가능한 필드 정의입니다.
+// optional 필드(json:"-")는 기본 출력에서 제외되며 명시적으로 포함해야 출력됩니다.
+// omitEmpty 필드는 기본 JSON 출력과 같이 값이 없으면 출력하지 않습니다.
+type fieldDef struct {
+	name      string
+	index     int
+	optional  bool
+	omitEmpty bool
+}
+
+// projectionTypes는 레벨별 구조체 타입입니다.
+var projectionTypes = map[string]reflect.Type{
+	LevelResult:    reflect.TypeOf(GroupedTrivyResult{}),
+	LevelTarget:    reflect.TypeOf(GroupedResult{}),
+	LevelPolicy:    reflect.TypeOf(GroupedMisconfiguration{}),
+	LevelViolation: reflect.TypeOf(Violation{}),
+}
+
+// projectionFields는 레벨별 출력 가능한 필드 목록입니다 (구조체 필드 순서 유지).
+var projectionFields = buildProjectionFieldsInternal()
+
+// buildProjectionFieldsInternal은 레벨별 구조체의 json 태그로 필드 목록을 만듭니다.
+func buildProjectionFieldsInternal() map[string][]fieldDef {
+	fields := make(map[string][]fieldDef, len(projectionTypes))
+	for level, t := range projectionTypes {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+			def := fieldDef{name: name, index: i, omitEmpty: strings.Contains(options, "omitempty")}
+			switch name {
+			case "-":
+				def.name = field.Name
+				def.optional = true
+			case "":
+				def.name = field.Name
+			}
+			fields[level] = append(fields[level], def)
+		}
+	}
+	return fields
+}
+
+// Projection은 preprocess 출력에 포함할 필드 선택 규칙입니다.
+type Projection struct {
+	enabled map[string]map[string]bool // 레벨 -> 필드 -> 출력 여부
+}
+
+// ParseProjection은 필드 선택 규칙 문자열을 파싱합니다.
+//
+// 형식: "<레벨>:<필드>,<필드>;<레벨>:..."
+//   - "+필드"는 기본 출력에 추가, "-필드"는 기본 출력에서 제외
+//   - 접두사 없는 필드를 나열하면 해당 레벨은 나열된 필드만 출력
+//
+// 예: "policy:+AVDID,+References,-Description;violation:-Provider,+Code"
+func ParseProjection(spec string) (*Projection, error) {
+	p := &Projection{enabled: make(map[string]map[string]bool)}
+	for level, fields := range projectionFields {
+		p.enabled[level] = make(map[string]bool)
+		for _, field := range fields {
+			p.enabled[level][field.name] = !field.optional
+		}
+	}
+
+	for _, clause := range strings.Split(spec, ";") {
+		clause = strings.TrimSpace(clause)
+		if clause == "" {
+			continue
+		}
+
+		level, list, ok := strings.Cut(clause, ":")
+		level = strings.ToLower(strings.TrimSpace(level))
+		if !ok {
+			return nil, fmt.Errorf("invalid field spec %q: expected <level>:<fields>", clause)
+		}
+		if _, exists := projectionFields[level]; !exists {
+			return nil, fmt.Errorf("unknown field level: %q (supported: result, target, policy, violation)", level)
+		}
+
+		var exact []string
+		for _, name := range strings.Split(list, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+
+			switch name[0] {
+			case '+', '-':
+				field, err := lookupFieldInternal(level, name[1:])
+				if err != nil {
+					return nil, err
+				}
+				p.enabled[level][field] = name[0] == '+'
+			default:
+				field, err := lookupFieldInternal(level, name)
+				if err != nil {
+					return nil, err
+				}
+				exact = append(exact, field)
+			}
+		}
+
+		// 나열한 필드만 출력
+		if len(exact) > 0 {
+			for field := range p.enabled[level] {
+				p.enabled[level][field] = false
+			}
+			for _, field := range exact {
+				p.enabled[level][field] = true
+			}
+		}
+	}
+
+	return p, nil
+}
+
+// lookupFieldInternal은 레벨에 정의된 필드 이름을 대소문자 구분 없이 찾습니다.
+func lookupFieldInternal(level, name string) (string, error) {
+	var names []string
+	for _, field := range projectionFields[level] {
+		if strings.EqualFold(field.name, name) {
+			return field.name, nil
+		}
+		names = append(names, field.name)
+	}
+	sort.Strings(names)
+	return "", fmt.Errorf("unknown %s field: %q (supported: %s)", level, name, strings.Join(names, ", "))
+}
+
+// orderedFields는 필드 정의 순서를 유지하며 JSON 객체로 직렬화됩니다.
+type orderedFields []orderedField
+
+type orderedField struct {
+	name  string
+	value interface{}
+}
+
+// MarshalJSON은 필드를 정의 순서대로 JSON 객체로 직렬화합니다.
+func (o orderedFields) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(field.name)
+		value, err := json.Marshal(field.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// selectInternal은 레벨에서 활성화된 필드만 정의 순서대로 모읍니다.
+// nested에 값이 있는 필드는 구조체 값 대신 nested 값(하위 레벨 변환 결과 등)을 사용합니다.
+func (p *Projection) selectInternal(level string, value reflect.Value, nested map[string]interface{}) orderedFields {
+	fields := orderedFields{}
+	for _, field := range projectionFields[level] {
+		if !p.enabled[level][field.name] {
+			continue
+		}
+		fieldValue := value.Field(field.index)
+		if field.omitEmpty && isEmptyValueInternal(fieldValue) {
+			continue
+		}
+		if v, exists := nested[field.name]; exists {
+			fields = append(fields, orderedField{name: field.name, value: v})
+		} else {
+			fields = append(fields, orderedField{name: field.name, value: fieldValue.Interface()})
+		}
+	}
+	return fields
+}
+
+// isEmptyValueInternal은 encoding/json의 omitempty와 같은 기준으로 빈 값인지 확인합니다.
+func isEmptyValueInternal(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Pointer:
+		return v.IsNil()
+	}
+	return false
+}
+
+// Apply는 그룹화된 결과를 필드 선택 규칙에 따라 JSON 직렬화용 값으로 변환합니다.
+func (p *Projection) Apply(result *GroupedTrivyResult) interface{} {
+	results := make([]interface{}, 0, len(result.Results))
+	for _, res := range result.Results {
+		results = append(results, p.Target(res))
+	}
+	return p.selectInternal(LevelResult, reflect.ValueOf(*result), map[string]interface{}{"Results": results})
+}
+
+// Target은 GroupedResult를 필드 선택 규칙에 따라 변환합니다.
+func (p *Projection) Target(result GroupedResult) interface{} {
+	misconfigs := make([]interface{}, 0, len(result.Misconfigurations))
+	for _, misconfig := range result.Misconfigurations {
+		misconfigs = append(misconfigs, p.Policy(misconfig))
+	}
+	return p.selectInternal(LevelTarget, reflect.ValueOf(result), map[string]interface{}{"Misconfigurations": misconfigs})
+}
+
+// Policy는 GroupedMisconfiguration을 필드 선택 규칙에 따라 변환합니다.
+func (p *Projection) Policy(misconfig GroupedMisconfiguration) interface{} {
+	violations := make([]interface{}, 0, len(misconfig.Violations))
+	for _, violation := range misconfig.Violations {
+		violations = append(violations, p.Violation(violation))
+	}
+	return p.selectInternal(LevelPolicy, reflect.ValueOf(misconfig), map[string]interface{}{"Violations": violations})
+}
+
+// Violation은 Violation을 필드 선택 규칙에 따라 변환합니다.
+// Code는 원인 라인이 표시된 일반 텍스트 스니펫으로 출력합니다.
+func (p *Projection) Violation(violation Violation) interface{} {
+	return p.selectInternal(LevelViolation, reflect.ValueOf(violation), map[string]interface{}{"Code": FormatCodeSnippet(violation.Code)})
+}
@@ -0,0 +1,288 @@
+package processor
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RegoMetadata는 로컬 Rego 정책 파일의 METADATA 주석에서 읽은 정책 정보입니다.
+// Trivy 스캔 결과에 포함되지 않는 필드(담당 팀, 조치 예시, 관련 링크, 위험 근거)를 보강하는 데 사용합니다.
+type RegoMetadata struct {
+	Namespace           string   // package 이름 (예: user.aws.s3.s3001)
+	File                string   // 정책 파일 경로
+	Title               string   // title
+	Description         string   // description
+	Resolution          string   // custom.recommended_actions
+	Owner               string   // custom.owner (없으면 organizations의 첫 항목)
+	RemediationExamples string   // custom.remediation_examples
+	RelatedResources    []string // related_resources
+	RiskRationale       string   // custom.risk_rationale
+
+	// subpackages는 scope가 subpackages인 블록으로, 하위 package에도 적용합니다 (PolicyMetadataIndex.Lookup).
+	subpackages *RegoMetadata
+}
+
+// PolicyMetadataIndex는 namespace를 키로 하는 Rego 정책 메타데이터 모음입니다.
+type PolicyMetadataIndex map[string]*RegoMetadata
+
+// regoAnnotationInternal은 OPA METADATA 주석의 YAML 형식입니다.
+type regoAnnotationInternal struct {
+	Scope            string                 `yaml:"scope"`
+	Title            string                 `yaml:"title"`
+	Description      string                 `yaml:"description"`
+	Organizations    []string               `yaml:"organizations"`
+	RelatedResources []interface{}          `yaml:"related_resources"` // 문자열 또는 {ref, description}
+	Custom           map[string]interface{} `yaml:"custom"`
+}
+
+// ParseRegoMetadata는 Rego 파일 내용에서 package 이름과 package 범위 METADATA 주석을 파싱합니다.
+// package 선언 앞의 METADATA 블록(사이에 빈 줄 허용)과 scope가 package/subpackages인 블록을 사용하며, rule 범위 블록은 무시합니다.
+// METADATA가 없으면 nil을 반환합니다.
+func ParseRegoMetadata(file string, content []byte) (*RegoMetadata, error) {
+	var namespace string
+	var blocks, subpackages []regoAnnotationInternal
+
+	var block []string
+	inBlock := false
+	collecting := false // 빈 줄 이후의 주석은 METADATA 블록에 포함하지 않음
+	flush := func(beforePackage bool) error {
+		if !inBlock {
+			return nil
+		}
+		inBlock, collecting = false, false
+
+		var annotation regoAnnotationInternal
+		if err := yaml.Unmarshal([]byte(strings.Join(block, "\n")), &annotation); err != nil {
+			return fmt.Errorf("%s: invalid METADATA: %w", file, err)
+		}
+		scope := annotation.Scope
+		if scope == "" && beforePackage {
+			scope = "package"
+		}
+		if scope == "package" || scope == "subpackages" {
+			blocks = append(blocks, annotation)
+		}
+		if scope == "subpackages" {
+			subpackages = append(subpackages, annotation)
+		}
+		return nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "#") {
+			comment := strings.TrimPrefix(trimmed, "#")
+			if strings.TrimSpace(comment) == "METADATA" {
+				if err := flush(false); err != nil {
+					return nil, err
+				}
+				inBlock, collecting, block = true, true, nil
+				continue
+			}
+			if collecting {
+				// "# " 접두사 제거 (YAML 들여쓰기 유지)
+				block = append(block, strings.TrimPrefix(comment, " "))
+			}
+			continue
+		}
+		if trimmed == "" {
+			// 블록은 다음 선언(package 또는 rule)에 연결
+			collecting = false
+			continue
+		}
+
+		isPackage := strings.HasPrefix(trimmed, "package ")
+		if err := flush(isPackage && namespace == ""); err != nil {
+			return nil, err
+		}
+		if isPackage && namespace == "" {
+			namespace = strings.TrimSpace(strings.TrimPrefix(trimmed, "package "))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	if err := flush(false); err != nil {
+		return nil, err
+	}
+
+	if namespace == "" || len(blocks) == 0 {
+		return nil, nil
+	}
+
+	metadata := &RegoMetadata{Namespace: namespace, File: file}
+	for _, annotation := range blocks {
+		metadata.merge(annotation.toMetadataInternal())
+	}
+	for _, annotation := range subpackages {
+		if metadata.subpackages == nil {
+			metadata.subpackages = &RegoMetadata{Namespace: namespace, File: file}
+		}
+		metadata.subpackages.merge(annotation.toMetadataInternal())
+	}
+	return metadata, nil
+}
+
+// toMetadataInternal은 METADATA 주석을 RegoMetadata 필드로 변환합니다.
+func (a regoAnnotationInternal) toMetadataInternal() *RegoMetadata {
+	metadata := &RegoMetadata{
+		Title:               strings.TrimSpace(a.Title),
+		Description:         strings.TrimSpace(a.Description),
+		Resolution:          customStringInternal(a.Custom, "recommended_actions"),
+		Owner:               customStringInternal(a.Custom, "owner"),
+		RemediationExamples: customStringInternal(a.Custom, "remediation_examples"),
+		RiskRationale:       customStringInternal(a.Custom, "risk_rationale"),
+	}
+	if metadata.Owner == "" && len(a.Organizations) > 0 {
+		metadata.Owner = a.Organizations[0]
+	}
+
+	for _, resource := range a.RelatedResources {
+		switch r := resource.(type) {
+		case string:
+			metadata.RelatedResources = append(metadata.RelatedResources, r)
+		case map[string]interface{}:
+			if ref, ok := r["ref"].(string); ok {
+				metadata.RelatedResources = append(metadata.RelatedResources, ref)
+			}
+		}
+	}
+	return metadata
+}
+
+// customStringInternal은 custom 필드 값을 문자열로 반환합니다. 목록이면 줄바꿈으로 이어 붙입니다.
+func customStringInternal(custom map[string]interface{}, key string) string {
+	switch value := custom[key].(type) {
+	case string:
+		return strings.TrimSpace(value)
+	case []interface{}:
+		lines := make([]string, 0, len(value))
+		for _, item := range value {
+			lines = append(lines, strings.TrimSpace(fmt.Sprint(item)))
+		}
+		return strings.Join(lines, "\n")
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// merge는 비어 있는 필드를 other의 값으로 채웁니다.
+func (m *RegoMetadata) merge(other *RegoMetadata) {
+	fill := func(dst *string, src string) {
+		if *dst == "" {
+			*dst = src
+		}
+	}
+	fill(&m.Title, other.Title)
+	fill(&m.Description, other.Description)
+	fill(&m.Resolution, other.Resolution)
+	fill(&m.Owner, other.Owner)
+	fill(&m.RemediationExamples, other.RemediationExamples)
+	fill(&m.RiskRationale, other.RiskRationale)
+	m.RelatedResources = mergeSortedInternal(m.RelatedResources, other.RelatedResources)
+	if other.subpackages != nil {
+		if m.subpackages == nil {
+			m.subpackages = &RegoMetadata{Namespace: m.Namespace, File: m.File}
+		}
+		m.subpackages.merge(other.subpackages)
+	}
+}
+
+// Add는 메타데이터를 인덱스에 추가합니다. 같은 namespace가 이미 있으면 비어 있는 필드만 채웁니다.
+func (idx PolicyMetadataIndex) Add(metadata *RegoMetadata) {
+	if existing, exists := idx[metadata.Namespace]; exists {
+		existing.merge(metadata)
+		return
+	}
+	idx[metadata.Namespace] = metadata
+}
+
+// Lookup은 namespace의 메타데이터를 반환합니다 (없으면 nil).
+// 비어 있는 필드는 가까운 상위 package부터 scope가 subpackages인 메타데이터로 채웁니다.
+func (idx PolicyMetadataIndex) Lookup(namespace string) *RegoMetadata {
+	var metadata *RegoMetadata
+	if existing, exists := idx[namespace]; exists {
+		copied := *existing
+		metadata = &copied
+	}
+	for parent := namespace; ; {
+		i := strings.LastIndex(parent, ".")
+		if i < 0 {
+			break
+		}
+		parent = parent[:i]
+		ancestor, exists := idx[parent]
+		if !exists || ancestor.subpackages == nil {
+			continue
+		}
+		if metadata == nil {
+			metadata = &RegoMetadata{Namespace: namespace, File: ancestor.File}
+		}
+		metadata.merge(ancestor.subpackages)
+	}
+	return metadata
+}
+
+// EnrichResults는 Preprocess 결과의 정책에 namespace가 같은 Rego 메타데이터를 병합합니다.
+// 스캔 결과의 Title, Description, Resolution이 비어 있으면 Rego 값으로 채웁니다.
+func (idx PolicyMetadataIndex) EnrichResults(targetMap map[string]*GroupedTrivyResult) {
+	for _, targetResult := range targetMap {
+		for r := range targetResult.Results {
+			misconfigs := targetResult.Results[r].Misconfigurations
+			for i := range misconfigs {
+				metadata := idx.Lookup(misconfigs[i].Namespace)
+				if metadata == nil {
+					continue
+				}
+
+				misconfig := &misconfigs[i]
+				misconfig.Owner = metadata.Owner
+				misconfig.RemediationExamples = metadata.RemediationExamples
+				misconfig.RelatedResources = metadata.RelatedResources
+				misconfig.RiskRationale = metadata.RiskRationale
+				fillEmptyInternal(&misconfig.Title, metadata.Title)
+				fillEmptyInternal(&misconfig.Description, metadata.Description)
+				fillEmptyInternal(&misconfig.Resolution, metadata.Resolution)
+			}
+		}
+	}
+}
+
+// EnrichExcelData는 Excel 행에 namespace가 같은 Rego 메타데이터를 병합합니다.
+func (idx PolicyMetadataIndex) EnrichExcelData(data *ExcelData) {
+	data.PolicyMetadata = true
+	for c := range data.Categories {
+		rows := data.Categories[c].Rows
+		for i := range rows {
+			metadata := idx.Lookup(rows[i].Namespace)
+			if metadata == nil {
+				continue
+			}
+
+			row := &rows[i]
+			row.Owner = metadata.Owner
+			row.RemediationExamples = metadata.RemediationExamples
+			row.RelatedResources = strings.Join(metadata.RelatedResources, "\n")
+			row.RiskRationale = metadata.RiskRationale
+			fillEmptyInternal(&row.Title, metadata.Title)
+			fillEmptyInternal(&row.Description, metadata.Description)
+			fillEmptyInternal(&row.Resolution, metadata.Resolution)
+		}
+	}
+}
+
+// fillEmptyInternal은 dst가 비어 있으면 src로 채웁니다.
+func fillEmptyInternal(dst *string, src string) {
+	if strings.TrimSpace(*dst) == "" {
+		*dst = src
+	}
+}
@@ -0,0 +1,254 @@
+package processor
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRegoMetadata(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    *RegoMetadata // nil이면 메타데이터 없음
+		wantErr bool
+	}{
+		{
+			name: "package block before package",
+			content: `# METADATA
+# title: S3 bucket must be encrypted
+# description: |
+#   Unencrypted buckets
+#   leak data.
+# organizations:
+#   - "@org/storage"
+# related_resources:
+#   - https://example.com/s3
+#   - ref: https://example.com/kms
+#     description: KMS
+# custom:
+#   recommended_actions: Enable SSE
+#   remediation_examples:
+#     - server_side_encryption_configuration {}
+#     - kms_master_key_id = aws_kms_key.this.arn
+#   risk_rationale: Data exposure
+package user.aws.s3.s3001
+
+deny[res] { true }`,
+			want: &RegoMetadata{
+				Namespace:           "user.aws.s3.s3001",
+				File:                "s3.rego",
+				Title:               "S3 bucket must be encrypted",
+				Description:         "Unencrypted buckets\nleak data.",
+				Resolution:          "Enable SSE",
+				Owner:               "@org/storage",
+				RemediationExamples: "server_side_encryption_configuration {}\nkms_master_key_id = aws_kms_key.this.arn",
+				RelatedResources:    []string{"https://example.com/kms", "https://example.com/s3"},
+				RiskRationale:       "Data exposure",
+			},
+		},
+		{
+			name: "custom owner overrides organizations",
+			content: `# METADATA
+# title: T
+# organizations: ["@org/a"]
+# custom:
+#   owner: "@org/b"
+package p`,
+			want: &RegoMetadata{Namespace: "p", File: "s3.rego", Title: "T", Owner: "@org/b", RelatedResources: []string{}},
+		},
+		{
+			name: "explicit package scope after package",
+			content: `package p
+
+# METADATA
+# scope: package
+# title: After
+deny { true }`,
+			want: &RegoMetadata{Namespace: "p", File: "s3.rego", Title: "After", RelatedResources: []string{}},
+		},
+		{
+			name: "rule scope and unscoped rule blocks ignored",
+			content: `# METADATA
+# scope: subpackages
+# title: Parent
+package p
+
+# METADATA
+# title: Rule without scope
+deny { true }
+
+# METADATA
+# scope: rule
+# description: Rule
+warn { true }`,
+			want: &RegoMetadata{
+				Namespace: "p", File: "s3.rego", Title: "Parent", RelatedResources: []string{},
+				subpackages: &RegoMetadata{Namespace: "p", File: "s3.rego", Title: "Parent", RelatedResources: []string{}},
+			},
+		},
+		{
+			name: "first block wins when merging",
+			content: `# METADATA
+# title: First
+package p
+
+# METADATA
+# scope: package
+# title: Second
+# description: From second`,
+			want: &RegoMetadata{Namespace: "p", File: "s3.rego", Title: "First", Description: "From second", RelatedResources: []string{}},
+		},
+		{
+			name: "blank line before package",
+			content: `# METADATA
+# title: Detached
+
+package p`,
+			want: &RegoMetadata{Namespace: "p", File: "s3.rego", Title: "Detached", RelatedResources: []string{}},
+		},
+		{
+			name: "blank line ends the block",
+			content: `# METADATA
+# title: Kept
+
+# description: ordinary comment
+package p`,
+			want: &RegoMetadata{Namespace: "p", File: "s3.rego", Title: "Kept", RelatedResources: []string{}},
+		},
+		{
+			name: "blank line before rule",
+			content: `package p
+
+# METADATA
+# title: Rule
+
+deny { true }`,
+		},
+		{
+			name: "no space after hash",
+			content: `#METADATA
+#title: Tight
+package p`,
+			want: &RegoMetadata{Namespace: "p", File: "s3.rego", Title: "Tight", RelatedResources: []string{}},
+		},
+		{
+			name:    "no metadata",
+			content: "# just a comment\npackage p\n",
+		},
+		{
+			name:    "no package",
+			content: "# METADATA\n# title: Orphan\n",
+		},
+		{
+			name:    "invalid yaml",
+			content: "# METADATA\n# title: [unclosed\npackage p\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRegoMetadata("s3.rego", []byte(tt.content))
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "s3.rego") {
+					t.Fatalf("err = %v, want METADATA error with file name", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRegoMetadata() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicyMetadataIndexAdd(t *testing.T) {
+	idx := make(PolicyMetadataIndex)
+	idx.Add(&RegoMetadata{Namespace: "p", Title: "First", RelatedResources: []string{"b"}})
+	idx.Add(&RegoMetadata{Namespace: "p", Title: "Second", Owner: "@org/x", RelatedResources: []string{"a", "b"}})
+
+	want := &RegoMetadata{Namespace: "p", Title: "First", Owner: "@org/x", RelatedResources: []string{"a", "b"}}
+	if got := idx["p"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("idx[p] = %+v, want %+v", got, want)
+	}
+}
+
+func TestPolicyMetadataIndexLookup(t *testing.T) {
+	files := map[string]string{
+		"aws.rego": `# METADATA
+# title: AWS policies
+# scope: subpackages
+# organizations: ["@org/platform"]
+# related_resources: ["https://example.com/aws"]
+package user.aws`,
+		"s3.rego": `# METADATA
+# scope: subpackages
+# custom:
+#   owner: "@org/storage"
+package user.aws.s3
+
+# METADATA
+# scope: package
+# title: S3 policies
+# custom:
+#   risk_rationale: Storage`,
+		"s3001.rego": `# METADATA
+# title: S3 bucket must be encrypted
+# related_resources: ["https://example.com/s3001"]
+package user.aws.s3.s3001`,
+	}
+	idx := make(PolicyMetadataIndex)
+	for _, name := range []string{"aws.rego", "s3.rego", "s3001.rego"} {
+		metadata, err := ParseRegoMetadata(name, []byte(files[name]))
+		if err != nil {
+			t.Fatal(err)
+		}
+		idx.Add(metadata)
+	}
+
+	tests := []struct {
+		namespace string
+		want      *RegoMetadata
+	}{
+		{
+			namespace: "user.aws",
+			want:      &RegoMetadata{Namespace: "user.aws", File: "aws.rego", Title: "AWS policies", Owner: "@org/platform", RelatedResources: []string{"https://example.com/aws"}},
+		},
+		{
+			// package 범위 값(RiskRationale)은 하위 package에 적용하지 않음
+			namespace: "user.aws.s3",
+			want:      &RegoMetadata{Namespace: "user.aws.s3", File: "s3.rego", Title: "S3 policies", Owner: "@org/storage", RiskRationale: "Storage", RelatedResources: []string{"https://example.com/aws"}},
+		},
+		{
+			namespace: "user.aws.s3.s3001",
+			want:      &RegoMetadata{Namespace: "user.aws.s3.s3001", File: "s3001.rego", Title: "S3 bucket must be encrypted", Owner: "@org/storage", RelatedResources: []string{"https://example.com/aws", "https://example.com/s3001"}},
+		},
+		{
+			// 파일이 없는 하위 package는 상위 subpackages 메타데이터만 사용
+			namespace: "user.aws.ec2.ec2001",
+			want:      &RegoMetadata{Namespace: "user.aws.ec2.ec2001", File: "aws.rego", Title: "AWS policies", Owner: "@org/platform", RelatedResources: []string{"https://example.com/aws"}},
+		},
+		{namespace: "user.awsx.s3"},
+		{namespace: "user"},
+		{namespace: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.namespace, func(t *testing.T) {
+			got := idx.Lookup(tt.namespace)
+			if got != nil {
+				got.subpackages = nil
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lookup(%q) = %+v, want %+v", tt.namespace, got, tt.want)
+			}
+		})
+	}
+
+	// Lookup은 인덱스를 변경하지 않음
+	if got := idx["user.aws.s3.s3001"]; got.Owner != "" || len(got.RelatedResources) != 1 {
+		t.Errorf("index modified: %+v", got)
+	}
+}
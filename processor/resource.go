@@ -0,0 +1,282 @@
+package processor
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// GroupBy는 preprocess 그룹화 기준입니다.
+type GroupBy string
+
+const (
+	GroupByPolicy   GroupBy = "policy"   // 정책 ID (기본)
+	GroupByResource GroupBy = "resource" // CauseMetadata.Resource (예: aws_s3_bucket.logs)
+	GroupByService  GroupBy = "service"  // CauseMetadata.Service (예: s3)
+	GroupByProvider GroupBy = "provider" // CauseMetadata.Provider (예: AWS)
+	GroupByModule   GroupBy = "module"   // 호출한 모듈 인스턴스 (모듈 밖이면 root)
+)
+
+// GroupByTagPrefix는 리소스 태그 값 기준 그룹화 접두사입니다 (예: "tag:Owner", -terraform-root 필요).
+const GroupByTagPrefix = "tag:"
+
+// ParseGroupBy는 문자열을 GroupBy로 변환합니다.
+func ParseGroupBy(s string) (GroupBy, error) {
+	if strings.HasPrefix(strings.ToLower(s), GroupByTagPrefix) && len(s) > len(GroupByTagPrefix) {
+		// 태그 키는 대소문자를 유지
+		return GroupBy(GroupByTagPrefix + s[len(GroupByTagPrefix):]), nil
+	}
+	switch groupBy := GroupBy(strings.ToLower(s)); groupBy {
+	case GroupByPolicy, GroupByResource, GroupByService, GroupByProvider, GroupByModule:
+		return groupBy, nil
+	}
+	return "", fmt.Errorf("unknown group-by: %q (supported: policy, resource, service, provider, module, tag:<key>)", s)
+}
+
+// TagKey는 태그 기준 그룹화의 태그 키를 반환합니다 (태그 기준이 아니면 빈 문자열).
+func (g GroupBy) TagKey() string {
+	if !strings.HasPrefix(string(g), GroupByTagPrefix) {
+		return ""
+	}
+	return strings.TrimPrefix(string(g), GroupByTagPrefix)
+}
+
+// 리소스 기준 그룹화 결과 구조체 (GroupedTrivyResult에 대응)
+type ResourceGroupedTrivyResult struct {
+	SchemaVersion   int                     `json:"SchemaVersion"`
+	CreatedAt       string                  `json:"CreatedAt"`
+	ArtifactName    string                  `json:"ArtifactName"`
+	ArtifactType    string                  `json:"ArtifactType"`
+	GroupBy         GroupBy                 `json:"GroupBy"`
+	Owner           string                  `json:"Owner,omitempty"`
+	SeveritySummary *SeveritySummary        `json:"SeveritySummary,omitempty"`
+	Results         []ResourceGroupedResult `json:"Results"`
+}
+
+type ResourceGroupedResult struct {
+	Target         string          `json:"Target"`
+	Class          string          `json:"Class"`
+	Type           string          `json:"Type"`
+	MisconfSummary MisconfSummary  `json:"MisconfSummary"`
+	Groups         []ResourceGroup `json:"Groups"`
+}
+
+// ResourceGroup은 리소스(또는 서비스/프로바이더/모듈) 1개에 대해 위반한 정책 목록입니다.
+type ResourceGroup struct {
+	Key             string           `json:"Key"`
+	SeveritySummary SeveritySummary  `json:"SeveritySummary"`
+	Policies        []ResourcePolicy `json:"Policies"`
+}
+
+// ResourcePolicy는 리소스 그룹 내에서 위반한 정책과 해당 위치입니다.
+type ResourcePolicy struct {
+	ID         string      `json:"ID"`
+	Title      string      `json:"Title"`
+	Namespace  string      `json:"Namespace"`
+	Resolution string      `json:"Resolution"`
+	Severity   string      `json:"Severity"`
+	PrimaryURL string      `json:"PrimaryURL"`
+	Status     string      `json:"Status"`
+	Violations []Violation `json:"Violations"`
+
+	Compliance map[string][]string `json:"Compliance,omitempty"`
+	Owner      string              `json:"Owner,omitempty"`
+}
+
+// GroupByAxis는 정책 기준으로 그룹화된 결과를 다른 기준(리소스/서비스/프로바이더/모듈)으로 다시 그룹화합니다.
+// 타겟 분리와 builtin/custom 구분은 Preprocess 결과를 그대로 따릅니다.
+func GroupByAxis(input *GroupedTrivyResult, groupBy GroupBy) *ResourceGroupedTrivyResult {
+	regrouped := &ResourceGroupedTrivyResult{
+		SchemaVersion:   input.SchemaVersion,
+		CreatedAt:       input.CreatedAt,
+		ArtifactName:    input.ArtifactName,
+		ArtifactType:    input.ArtifactType,
+		GroupBy:         groupBy,
+		Owner:           input.Owner,
+		SeveritySummary: input.SeveritySummary,
+		Results:         make([]ResourceGroupedResult, 0, len(input.Results)),
+	}
+
+	for _, result := range input.Results {
+		// 그룹 키별로 묶기 위한 맵 (출력 순서는 최초 등장 순서)
+		groupMap := make(map[string]*ResourceGroup)
+		var groupOrder []string
+
+		for _, misconfig := range result.Misconfigurations {
+			for _, violation := range misconfig.Violations {
+				key := groupKeyInternal(violation, groupBy)
+
+				group, exists := groupMap[key]
+				if !exists {
+					group = &ResourceGroup{Key: key, Policies: []ResourcePolicy{}}
+					groupMap[key] = group
+					groupOrder = append(groupOrder, key)
+				}
+
+				// 같은 그룹 내 같은 정책이면 violation만 추가
+				last := len(group.Policies) - 1
+				if last >= 0 && group.Policies[last].ID == misconfig.ID {
+					group.Policies[last].Violations = append(group.Policies[last].Violations, violation)
+					continue
+				}
+
+				group.Policies = append(group.Policies, ResourcePolicy{
+					ID:         misconfig.ID,
+					Title:      misconfig.Title,
+					Namespace:  misconfig.Namespace,
+					Resolution: misconfig.Resolution,
+					Severity:   misconfig.Severity,
+					PrimaryURL: misconfig.PrimaryURL,
+					Status:     misconfig.Status,
+					Violations: []Violation{violation},
+					Compliance: misconfig.Compliance,
+					Owner:      misconfig.Owner,
+				})
+				group.SeveritySummary.addSeverity(misconfig.Severity)
+			}
+		}
+
+		groups := make([]ResourceGroup, 0, len(groupOrder))
+		for _, key := range groupOrder {
+			groups = append(groups, *groupMap[key])
+		}
+
+		regrouped.Results = append(regrouped.Results, ResourceGroupedResult{
+			Target:         result.Target,
+			Class:          result.Class,
+			Type:           result.Type,
+			MisconfSummary: result.MisconfSummary,
+			Groups:         groups,
+		})
+	}
+
+	return regrouped
+}
+
+// groupKeyInternal은 violation의 그룹 키를 반환합니다.
+func groupKeyInternal(violation Violation, groupBy GroupBy) string {
+	switch groupBy {
+	case GroupByService:
+		return violation.Service
+	case GroupByProvider:
+		return violation.Provider
+	case GroupByModule:
+		return ModuleName(violation.Module)
+	}
+	if key := groupBy.TagKey(); key != "" {
+		return violation.TagValue(key)
+	}
+	return violation.Resource
+}
+
+// addSeverity는 심각도 1건을 카운트에 더합니다.
+func (s *SeveritySummary) addSeverity(severity string) {
+	switch strings.ToUpper(severity) {
+	case "CRITICAL":
+		s.Critical++
+	case "HIGH":
+		s.High++
+	case "MEDIUM":
+		s.Medium++
+	case "LOW":
+		s.Low++
+	}
+}
+
+// SortResourceGroupedResult는 리소스 기준 그룹화 결과를 정렬 기준에 따라 정렬합니다.
+// severity는 그룹의 가장 높은 심각도, policy는 그룹 키와 정책 ID, line은 가장 앞선 라인, risk는 가장 높은 위험 점수를 기준으로 합니다.
+func SortResourceGroupedResult(result *ResourceGroupedTrivyResult, keys []SortKey) {
+	if len(keys) == 0 {
+		return
+	}
+
+	sort.SliceStable(result.Results, func(i, j int) bool {
+		return compareByKeysInternal(keys, func(field string) int {
+			if field == SortByTarget {
+				return strings.Compare(result.Results[i].Target, result.Results[j].Target)
+			}
+			return 0
+		}) < 0
+	})
+
+	for r := range result.Results {
+		groups := result.Results[r].Groups
+		for g := range groups {
+			policies := groups[g].Policies
+			sort.SliceStable(policies, func(i, j int) bool {
+				a, b := policies[i], policies[j]
+				return compareByKeysInternal(keys, func(field string) int {
+					switch field {
+					case SortBySeverity:
+						return compareInt(SeverityRank(a.Severity), SeverityRank(b.Severity))
+					case SortByPolicy:
+						return strings.Compare(a.ID, b.ID)
+					case SortByLine:
+						return compareInt(firstLineInternal(a.Violations), firstLineInternal(b.Violations))
+					case SortByRisk:
+						return compareFloatInternal(maxViolationRiskInternal(b.Violations), maxViolationRiskInternal(a.Violations))
+					}
+					return 0
+				}) < 0
+			})
+		}
+
+		sort.SliceStable(groups, func(i, j int) bool {
+			a, b := groups[i], groups[j]
+			return compareByKeysInternal(keys, func(field string) int {
+				switch field {
+				case SortBySeverity:
+					return compareInt(worstSeverityRankInternal(a), worstSeverityRankInternal(b))
+				case SortByPolicy:
+					return strings.Compare(a.Key, b.Key)
+				case SortByLine:
+					return compareInt(groupFirstLineInternal(a), groupFirstLineInternal(b))
+				case SortByRisk:
+					return compareFloatInternal(groupMaxRiskInternal(b), groupMaxRiskInternal(a))
+				}
+				return 0
+			}) < 0
+		})
+	}
+}
+
+// worstSeverityRankInternal은 그룹 내 가장 높은 심각도의 순위를 반환합니다.
+func worstSeverityRankInternal(group ResourceGroup) int {
+	rank := SeverityRank("")
+	for _, policy := range group.Policies {
+		if r := SeverityRank(policy.Severity); r < rank {
+			rank = r
+		}
+	}
+	return rank
+}
+
+// groupFirstLineInternal은 그룹 내 가장 앞선 시작 라인을 반환합니다.
+func groupFirstLineInternal(group ResourceGroup) int {
+	var violations []Violation
+	for _, policy := range group.Policies {
+		violations = append(violations, policy.Violations...)
+	}
+	return firstLineInternal(violations)
+}
+
+// firstLineInternal은 violation 중 가장 앞선 시작 라인을 반환합니다.
+func firstLineInternal(violations []Violation) int {
+	line := 0
+	for i, violation := range violations {
+		if i == 0 || violation.StartLine < line {
+			line = violation.StartLine
+		}
+	}
+	return line
+}
+
+// groupMaxRiskInternal은 그룹 내 가장 높은 위험 점수를 반환합니다.
+func groupMaxRiskInternal(group ResourceGroup) float64 {
+	score := 0.0
+	for _, policy := range group.Policies {
+		score = math.Max(score, maxViolationRiskInternal(policy.Violations))
+	}
+	return score
+}
@@ -0,0 +1,424 @@
+package processor
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PriorityReportFilename은 preprocess 출력 디렉토리에 저장하는 우선순위 목록 파일 이름입니다.
+const PriorityReportFilename = "priority.json"
+
+// RiskConfig는 위험 점수 설정 파일 형식입니다. 생략한 항목은 DefaultRiskConfig 값을 사용합니다.
+// severity, category는 지정한 키만 기본값을 덮어쓰며, exposure, age는 지정하면 항목 전체를 바꿉니다.
+//
+//	{
+//	  "severity": {"CRITICAL": 10, "HIGH": 7, "MEDIUM": 4, "LOW": 1, "UNKNOWN": 0.5},
+//	  "category": {"custom": 1.2},
+//	  "exposure": {"weight": 1.5, "resources": ["aws_lb", "aws_api_gateway_*"], "keywords": ["public", "0.0.0.0/0"]},
+//	  "age": {"per_day": 0.01, "max": 2}
+//	}
+//
+// 점수 = 심각도 점수 × 카테고리 배수 × 태그 가중치(-tag-weights) × 노출 배수 × 경과 배수
+type RiskConfig struct {
+	Severity map[string]float64 `json:"severity,omitempty"` // 심각도별 기본 점수
+	Category map[string]float64 `json:"category,omitempty"` // 정책 카테고리별 배수 (없으면 1)
+	Exposure *ExposureConfig    `json:"exposure,omitempty"`
+	Age      *AgeConfig         `json:"age,omitempty"`
+}
+
+// ExposureConfig는 외부 노출(public-facing) 리소스 판단 기준입니다. 하나라도 일치하면 노출로 봅니다.
+type ExposureConfig struct {
+	Weight    float64  `json:"weight"`              // 노출 리소스의 배수 (0이면 기본값)
+	Resources []string `json:"resources,omitempty"` // 리소스 타입 패턴 (path.Match 형식, 예: "aws_api_gateway_*")
+	Policies  []string `json:"policies,omitempty"`  // 정책 ID/AVDID 패턴
+	Keywords  []string `json:"keywords,omitempty"`  // 정책 제목/메시지 키워드 (대소문자 무시)
+}
+
+// AgeConfig는 최초 발견 이후 경과일에 따른 배수입니다 (1 + 경과일 × PerDay, 최대 Max, Max가 0이면 기본값).
+type AgeConfig struct {
+	PerDay float64 `json:"per_day"`
+	Max    float64 `json:"max"`
+}
+
+// DefaultRiskConfig는 기본 위험 점수 설정을 반환합니다.
+// 커스텀 정책은 조직 기준으로 작성된 정책이므로 기본 정책보다 높은 배수를 사용합니다.
+func DefaultRiskConfig() RiskConfig {
+	return RiskConfig{
+		Severity: map[string]float64{"CRITICAL": 10, "HIGH": 7, "MEDIUM": 4, "LOW": 1, "UNKNOWN": 0.5},
+		Category: map[string]float64{CategoryCustom: 1.2, CategoryBuiltin: 1},
+		Exposure: &ExposureConfig{
+			Weight: 1.5,
+			Resources: []string{
+				"aws_lb", "aws_alb", "aws_elb", "aws_lb_listener", "aws_alb_listener",
+				"aws_api_gateway_*", "aws_apigatewayv2_*",
+				"aws_cloudfront_distribution", "aws_lambda_function_url",
+			},
+			Keywords: []string{"public", "0.0.0.0/0", "::/0", "internet", "퍼블릭"},
+		},
+		Age: &AgeConfig{PerDay: 0.01, Max: 2},
+	}
+}
+
+// RiskModel은 finding별 위험 점수를 계산합니다.
+type RiskModel struct {
+	config  RiskConfig
+	weights []TagWeight
+}
+
+// NewRiskModel은 설정을 기본값과 합쳐 검증하고 위험 점수 모델을 생성합니다.
+// tagWeights는 -tag-weights 옵션의 태그 가중치입니다 (없으면 태그 배수 1).
+func NewRiskModel(config RiskConfig, tagWeights []TagWeight) (*RiskModel, error) {
+	defaults := DefaultRiskConfig()
+	severity := defaults.Severity
+	for key, value := range config.Severity {
+		severity[strings.ToUpper(key)] = value
+	}
+	category := defaults.Category
+	for key, value := range config.Category {
+		category[key] = value
+	}
+	config.Severity, config.Category = severity, category
+	if config.Exposure == nil {
+		config.Exposure = defaults.Exposure
+	} else if config.Exposure.Weight == 0 {
+		config.Exposure.Weight = defaults.Exposure.Weight
+	}
+	if config.Age == nil {
+		config.Age = defaults.Age
+	} else if config.Age.Max == 0 {
+		config.Age.Max = defaults.Age.Max
+	}
+
+	for key, value := range config.Severity {
+		if value < 0 {
+			return nil, fmt.Errorf("invalid severity score for %s: must be non-negative", key)
+		}
+	}
+	for key, value := range config.Category {
+		if value < 0 {
+			return nil, fmt.Errorf("invalid category weight for %s: must be non-negative", key)
+		}
+	}
+	if config.Exposure.Weight < 0 || config.Age.PerDay < 0 || config.Age.Max < 0 {
+		return nil, fmt.Errorf("invalid risk config: exposure weight and age factors must be non-negative")
+	}
+	for _, pattern := range append(append([]string{}, config.Exposure.Resources...), config.Exposure.Policies...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid exposure pattern %q: %w", pattern, err)
+		}
+	}
+
+	return &RiskModel{config: config, weights: tagWeights}, nil
+}
+
+// RiskScore는 finding 1개의 위험 점수와 계산에 사용한 배수입니다.
+type RiskScore struct {
+	Score    float64 `json:"Score"`
+	Severity float64 `json:"Severity"` // 심각도 점수
+	Category float64 `json:"Category"` // 정책 카테고리 배수
+	Tag      float64 `json:"Tag"`      // 리소스 태그 가중치
+	Exposure float64 `json:"Exposure"` // 외부 노출 배수 (노출이 아니면 1)
+	Age      float64 `json:"Age"`      // 경과 배수 (이력이 없으면 1)
+	AgeDays  int     `json:"AgeDays"`  // 최초 발견 이후 경과일
+	Exposed  bool    `json:"Exposed"`
+}
+
+// riskInputInternal은 점수 계산에 필요한 finding 정보입니다.
+type riskInputInternal struct {
+	category string
+	severity string
+	policyID string
+	avdID    string
+	title    string
+	message  string
+	resource string
+	tags     map[string]string
+	age      int
+}
+
+// scoreInternal은 finding 1개의 위험 점수를 계산합니다.
+func (m *RiskModel) scoreInternal(in riskInputInternal) *RiskScore {
+	score := &RiskScore{
+		Severity: m.severityScoreInternal(in.severity),
+		Category: 1,
+		Tag:      TagWeightOf(m.weights, in.tags),
+		Exposure: 1,
+		Age:      1,
+		AgeDays:  in.age,
+	}
+	if weight, exists := m.config.Category[in.category]; exists {
+		score.Category = weight
+	}
+	if m.exposedInternal(in) {
+		score.Exposed = true
+		score.Exposure = m.config.Exposure.Weight
+	}
+	if in.age > 0 {
+		score.Age = math.Min(1+float64(in.age)*m.config.Age.PerDay, math.Max(m.config.Age.Max, 1))
+	}
+
+	score.Score = roundScoreInternal(score.Severity * score.Category * score.Tag * score.Exposure * score.Age)
+	return score
+}
+
+func (m *RiskModel) severityScoreInternal(severity string) float64 {
+	if value, exists := m.config.Severity[strings.ToUpper(severity)]; exists {
+		return value
+	}
+	return m.config.Severity["UNKNOWN"]
+}
+
+// exposedInternal은 리소스 타입, 정책 ID, 제목/메시지 키워드로 외부 노출 여부를 판단합니다.
+func (m *RiskModel) exposedInternal(in riskInputInternal) bool {
+	exposure := m.config.Exposure
+	resourceType := ResourceType(in.resource)
+	for _, pattern := range exposure.Resources {
+		if ok, _ := path.Match(pattern, resourceType); ok && resourceType != "" {
+			return true
+		}
+	}
+	for _, pattern := range exposure.Policies {
+		if ok, _ := path.Match(pattern, in.policyID); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, in.avdID); ok && in.avdID != "" {
+			return true
+		}
+	}
+	text := strings.ToLower(in.title + "\n" + in.message)
+	for _, keyword := range exposure.Keywords {
+		if keyword != "" && strings.Contains(text, strings.ToLower(keyword)) {
+			return true
+		}
+	}
+	return false
+}
+
+// roundScoreInternal은 점수를 소수점 둘째 자리로 반올림합니다.
+func roundScoreInternal(score float64) float64 {
+	return math.Round(score*100) / 100
+}
+
+// ResourceType은 리소스 주소에서 리소스 타입을 추출합니다.
+// 인스턴스 키([0], ["a.b"])는 제거하고 봅니다.
+// 예: "module.app.aws_lb.public[0]" -> "aws_lb", "data.aws_iam_policy_document.x" -> "aws_iam_policy_document"
+func ResourceType(address string) string {
+	parts := strings.Split(stripInstanceKeysInternal(address), ".")
+	for i := 0; i < len(parts); i++ {
+		switch {
+		case parts[i] == "module":
+			i++ // 모듈 이름 건너뛰기
+		case parts[i] == "data":
+		default:
+			return parts[i]
+		}
+	}
+	return ""
+}
+
+// stripInstanceKeysInternal은 주소에서 "[...]" 인스턴스 키를 제거합니다 (따옴표 안의 '.', ']' 포함).
+func stripInstanceKeysInternal(address string) string {
+	var b strings.Builder
+	depth, quoted := 0, false
+	for i := 0; i < len(address); i++ {
+		c := address[i]
+		switch {
+		case quoted && c == '\\':
+			i++
+		case quoted:
+			quoted = c != '"'
+		case depth > 0 && c == '"':
+			quoted = true
+		case c == '[':
+			depth++
+		case c == ']' && depth > 0:
+			depth--
+		case depth == 0:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// FindingHistory는 finding 지문별 최초 발견 시각입니다 (-baseline 스캔 이력).
+type FindingHistory map[string]time.Time
+
+// BuildFindingHistory는 스캔 목록에서 finding별 최초 발견 시각을 구합니다.
+// CreatedAt을 파싱할 수 없는 스캔은 건너뜁니다.
+func BuildFindingHistory(scans []*TrivyResult) FindingHistory {
+	history := make(FindingHistory)
+	for _, scan := range scans {
+		createdAt, err := ParseCreatedAt(scan.CreatedAt)
+		if err != nil {
+			continue
+		}
+		for _, result := range scan.Results {
+			for _, misconfig := range result.Misconfigurations {
+				history.addInternal(Fingerprint(result.Target, misconfig.ID, misconfig.CauseMetadata.Resource), createdAt)
+			}
+		}
+	}
+	return history
+}
+
+// AgeDays는 현재 스캔 시각 기준 최초 발견 이후 경과일을 반환합니다 (이력에 없으면 0).
+func (h FindingHistory) AgeDays(fingerprint string, now time.Time) int {
+	first, exists := h[fingerprint]
+	if !exists || now.IsZero() || !first.Before(now) {
+		return 0
+	}
+	return int(now.Sub(first).Hours() / 24)
+}
+
+// PriorityReport는 위험 점수 순으로 정렬한 finding 목록입니다.
+type PriorityReport struct {
+	Total           int               `json:"Total"`
+	SeveritySummary SeveritySummary   `json:"SeveritySummary"`
+	Findings        []PriorityFinding `json:"Findings"`
+}
+
+// PriorityFinding은 우선순위 목록의 finding 1개입니다.
+type PriorityFinding struct {
+	Rank        int       `json:"Rank"`
+	Category    string    `json:"Category"`
+	Target      string    `json:"Target"`
+	PolicyID    string    `json:"PolicyID"`
+	Severity    string    `json:"Severity"`
+	Title       string    `json:"Title"`
+	Resource    string    `json:"Resource"`
+	StartLine   int       `json:"StartLine"`
+	EndLine     int       `json:"EndLine"`
+	Fingerprint string    `json:"Fingerprint"`
+	Risk        RiskScore `json:"Risk"`
+}
+
+// ScoreResults는 Preprocess 결과의 각 Violation에 위험 점수(Risk)를 채우고 우선순위 목록을 반환합니다.
+// now는 현재 스캔 시각이며, history가 nil이면 경과 배수를 적용하지 않습니다.
+func (m *RiskModel) ScoreResults(targetMap map[string]*GroupedTrivyResult, history FindingHistory, now time.Time) *PriorityReport {
+	var findings []PriorityFinding
+	for _, key := range SortedTargetKeys(targetMap) {
+		category, _ := SplitTargetKey(key, targetMap[key])
+		for _, result := range targetMap[key].Results {
+			for _, misconfig := range result.Misconfigurations {
+				for v := range misconfig.Violations {
+					violation := &misconfig.Violations[v]
+					fingerprint := Fingerprint(result.Target, misconfig.ID, violation.Resource)
+					in := riskInputInternal{
+						category: category,
+						severity: misconfig.Severity,
+						policyID: misconfig.ID,
+						avdID:    misconfig.AVDID,
+						title:    misconfig.Title,
+						message:  violation.Message,
+						resource: violation.Resource,
+						age:      history.AgeDays(fingerprint, now),
+					}
+					if violation.Terraform != nil {
+						in.tags = violation.Terraform.Tags
+					}
+					violation.Risk = m.scoreInternal(in)
+
+					findings = append(findings, PriorityFinding{
+						Category:    category,
+						Target:      result.Target,
+						PolicyID:    misconfig.ID,
+						Severity:    misconfig.Severity,
+						Title:       misconfig.Title,
+						Resource:    violation.Resource,
+						StartLine:   violation.StartLine,
+						EndLine:     violation.EndLine,
+						Fingerprint: fingerprint,
+						Risk:        *violation.Risk,
+					})
+				}
+			}
+		}
+	}
+	return newPriorityReportInternal(findings)
+}
+
+// ScoreExcelData는 Excel 행에 위험 점수를 채우고 Priority 시트용 우선순위 목록을 만듭니다.
+func (m *RiskModel) ScoreExcelData(data *ExcelData, history FindingHistory, now time.Time) {
+	var findings []PriorityFinding
+	for c := range data.Categories {
+		category := data.Categories[c].Name
+		rows := data.Categories[c].Rows
+		for i := range rows {
+			row := &rows[i]
+			row.Risk = m.scoreInternal(riskInputInternal{
+				category: category,
+				severity: row.Severity,
+				policyID: row.PolicyID,
+				avdID:    row.AVDID,
+				title:    row.Title,
+				message:  row.Message,
+				resource: row.Resource,
+				tags:     row.Tags,
+				age:      history.AgeDays(row.Fingerprint, now),
+			})
+
+			findings = append(findings, PriorityFinding{
+				Category:    category,
+				Target:      row.Target,
+				PolicyID:    row.PolicyID,
+				Severity:    row.Severity,
+				Title:       row.Title,
+				Resource:    row.Resource,
+				StartLine:   row.StartLine,
+				EndLine:     row.EndLine,
+				Fingerprint: row.Fingerprint,
+				Risk:        *row.Risk,
+			})
+		}
+	}
+	data.Priority = newPriorityReportInternal(findings)
+}
+
+// newPriorityReportInternal은 점수 내림차순으로 정렬하고 순위를 매깁니다.
+// 점수가 같으면 심각도, 타겟, 정책 ID, 라인 순으로 정렬하여 실행마다 같은 순서를 보장합니다.
+func newPriorityReportInternal(findings []PriorityFinding) *PriorityReport {
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Risk.Score != b.Risk.Score {
+			return a.Risk.Score > b.Risk.Score
+		}
+		if c := compareInt(SeverityRank(a.Severity), SeverityRank(b.Severity)); c != 0 {
+			return c < 0
+		}
+		if a.Target != b.Target {
+			return a.Target < b.Target
+		}
+		if a.PolicyID != b.PolicyID {
+			return a.PolicyID < b.PolicyID
+		}
+		return a.StartLine < b.StartLine
+	})
+
+	report := &PriorityReport{Findings: []PriorityFinding{}}
+	for i := range findings {
+		findings[i].Rank = i + 1
+		report.Findings = append(report.Findings, findings[i])
+		report.SeveritySummary.addSeverity(findings[i].Severity)
+	}
+	report.Total = len(report.Findings)
+	return report
+}
+
+// maxRiskScoreInternal은 정책의 violation 중 가장 높은 위험 점수를 반환합니다 (점수가 없으면 0).
+func maxRiskScoreInternal(misconfig GroupedMisconfiguration) float64 {
+	return maxViolationRiskInternal(misconfig.Violations)
+}
+
+func maxViolationRiskInternal(violations []Violation) float64 {
+	score := 0.0
+	for _, violation := range violations {
+		if violation.Risk != nil && violation.Risk.Score > score {
+			score = violation.Risk.Score
+		}
+	}
+	return score
+}
@@ -0,0 +1,190 @@
+package processor
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestResourceType(t *testing.T) {
+	tests := []struct {
+		address string
+		want    string
+	}{
+		{address: "aws_s3_bucket.logs", want: "aws_s3_bucket"},
+		{address: "module.app.aws_lb.public[0]", want: "aws_lb"},
+		{address: "module.app[0].aws_lb.public", want: "aws_lb"},
+		{address: `module.app["a.b"].aws_lb.public["x.y"]`, want: "aws_lb"},
+		{address: `module.app["a\"].b"].aws_alb.x`, want: "aws_alb"},
+		{address: "module.a.module.b.data.aws_iam_policy_document.x", want: "aws_iam_policy_document"},
+		{address: "data.aws_caller_identity.current", want: "aws_caller_identity"},
+		{address: "aws_lb[0]", want: "aws_lb"},
+		{address: "module.app", want: ""},
+		{address: "", want: ""},
+	}
+	for _, tt := range tests {
+		if got := ResourceType(tt.address); got != tt.want {
+			t.Errorf("ResourceType(%q) = %q, want %q", tt.address, got, tt.want)
+		}
+	}
+}
+
+func TestNewRiskModelMerge(t *testing.T) {
+	model, err := NewRiskModel(RiskConfig{
+		Severity: map[string]float64{"high": 9},
+		Category: map[string]float64{"security": 2},
+		Exposure: &ExposureConfig{Resources: []string{"aws_s3_bucket"}},
+		Age:      &AgeConfig{PerDay: 0.1},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := model.config
+
+	// severity, category는 지정한 키만 덮어씀
+	if config.Severity["HIGH"] != 9 || config.Severity["CRITICAL"] != 10 || config.Severity["UNKNOWN"] != 0.5 {
+		t.Errorf("Severity = %v", config.Severity)
+	}
+	if config.Category["security"] != 2 || config.Category[CategoryCustom] != 1.2 {
+		t.Errorf("Category = %v", config.Category)
+	}
+	// exposure, age는 항목 전체를 바꾸며 0인 배수/최대값만 기본값 사용
+	want := &ExposureConfig{Weight: 1.5, Resources: []string{"aws_s3_bucket"}}
+	if !reflect.DeepEqual(config.Exposure, want) {
+		t.Errorf("Exposure = %+v, want %+v", config.Exposure, want)
+	}
+	if *config.Age != (AgeConfig{PerDay: 0.1, Max: 2}) {
+		t.Errorf("Age = %+v", *config.Age)
+	}
+	if model.exposedInternal(riskInputInternal{resource: "aws_lb.public", title: "public load balancer"}) {
+		t.Error("default exposure rules should be replaced")
+	}
+	if !model.exposedInternal(riskInputInternal{resource: "module.m.aws_s3_bucket.b[0]"}) {
+		t.Error("configured exposure resource should match")
+	}
+
+	// 기본 설정은 공유되지 않음
+	if DefaultRiskConfig().Severity["HIGH"] != 7 {
+		t.Error("NewRiskModel modified DefaultRiskConfig")
+	}
+}
+
+func TestNewRiskModelInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		config RiskConfig
+	}{
+		{name: "negative severity", config: RiskConfig{Severity: map[string]float64{"LOW": -1}}},
+		{name: "negative category", config: RiskConfig{Category: map[string]float64{"custom": -0.5}}},
+		{name: "negative exposure weight", config: RiskConfig{Exposure: &ExposureConfig{Weight: -1}}},
+		{name: "negative per day", config: RiskConfig{Age: &AgeConfig{PerDay: -0.01}}},
+		{name: "negative max", config: RiskConfig{Age: &AgeConfig{Max: -2}}},
+		{name: "bad resource pattern", config: RiskConfig{Exposure: &ExposureConfig{Resources: []string{"aws_["}}}},
+		{name: "bad policy pattern", config: RiskConfig{Exposure: &ExposureConfig{Policies: []string{"AVD-["}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewRiskModel(tt.config, nil); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestRiskScore(t *testing.T) {
+	weights, err := ParseTagWeights("Environment=prod:2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	model, err := NewRiskModel(RiskConfig{}, weights)
+	if err != nil {
+		t.Fatal(err)
+	}
+	capped, err := NewRiskModel(RiskConfig{Age: &AgeConfig{PerDay: 0.5, Max: 0.5}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		model *RiskModel
+		in    riskInputInternal
+		want  RiskScore
+	}{
+		{
+			name:  "builtin high",
+			model: model,
+			in:    riskInputInternal{category: CategoryBuiltin, severity: "HIGH", resource: "aws_s3_bucket.a"},
+			want:  RiskScore{Score: 7, Severity: 7, Category: 1, Tag: 1, Exposure: 1, Age: 1},
+		},
+		{
+			name:  "custom exposed tagged aged",
+			model: model,
+			in:    riskInputInternal{category: CategoryCustom, severity: "high", resource: "module.app.aws_lb.public[0]", tags: map[string]string{"Environment": "prod"}, age: 10},
+			want:  RiskScore{Score: 27.72, Severity: 7, Category: 1.2, Tag: 2, Exposure: 1.5, Age: 1.1, AgeDays: 10, Exposed: true},
+		},
+		{
+			name:  "keyword exposure and unknown severity",
+			model: model,
+			in:    riskInputInternal{category: "other", severity: "weird", title: "Ingress from 0.0.0.0/0"},
+			want:  RiskScore{Score: 0.75, Severity: 0.5, Category: 1, Tag: 1, Exposure: 1.5, Age: 1, Exposed: true},
+		},
+		{
+			name:  "age capped at max",
+			model: model,
+			in:    riskInputInternal{category: CategoryBuiltin, severity: "LOW", age: 500},
+			want:  RiskScore{Score: 2, Severity: 1, Category: 1, Tag: 1, Exposure: 1, Age: 2, AgeDays: 500},
+		},
+		{
+			name:  "age max below one never lowers the score",
+			model: capped,
+			in:    riskInputInternal{category: CategoryBuiltin, severity: "LOW", age: 30},
+			want:  RiskScore{Score: 1, Severity: 1, Category: 1, Tag: 1, Exposure: 1, Age: 1, AgeDays: 30},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.model.scoreInternal(tt.in); *got != tt.want {
+				t.Errorf("score = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPriorityReportOrder(t *testing.T) {
+	finding := func(score float64, severity, target, policyID string, line int) PriorityFinding {
+		return PriorityFinding{Risk: RiskScore{Score: score}, Severity: severity, Target: target, PolicyID: policyID, StartLine: line}
+	}
+	findings := []PriorityFinding{
+		finding(7, "HIGH", "b.tf", "P1", 1),
+		finding(7, "HIGH", "a.tf", "P2", 9),
+		finding(7, "HIGH", "a.tf", "P2", 3),
+		finding(10, "LOW", "z.tf", "P9", 1),
+		finding(7, "CRITICAL", "z.tf", "P9", 1),
+		finding(7, "HIGH", "a.tf", "P1", 5),
+	}
+	report := newPriorityReportInternal(findings)
+
+	var got []string
+	for _, f := range report.Findings {
+		got = append(got, fmtFindingInternal(f))
+	}
+	want := []string{
+		"1 LOW z.tf P9 1",
+		"2 CRITICAL z.tf P9 1",
+		"3 HIGH a.tf P1 5",
+		"4 HIGH a.tf P2 3",
+		"5 HIGH a.tf P2 9",
+		"6 HIGH b.tf P1 1",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("order =\n%v\nwant\n%v", got, want)
+	}
+	if report.Total != 6 || report.SeveritySummary != (SeveritySummary{Critical: 1, High: 4, Low: 1}) {
+		t.Errorf("Total = %d, SeveritySummary = %+v", report.Total, report.SeveritySummary)
+	}
+}
+
+func fmtFindingInternal(f PriorityFinding) string {
+	return fmt.Sprintf("%d %s %s %s %d", f.Rank, f.Severity, f.Target, f.PolicyID, f.StartLine)
+}
@@ -0,0 +1,277 @@
+package processor
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// OverdueReportFilename은 preprocess 출력 디렉토리에 저장하는 SLA 초과 목록 파일 이름입니다.
+const OverdueReportFilename = "overdue.json"
+
+// slaDateLayout은 SLA 기한 날짜 형식입니다.
+const slaDateLayout = "2006-01-02"
+
+// SLAConfig는 조치 기한(일) 설정 파일 형식입니다. 생략한 심각도는 DefaultSLAConfig 값을 사용합니다.
+//
+//	{
+//	  "severity": {"CRITICAL": 7, "HIGH": 30, "MEDIUM": 90, "LOW": 180},
+//	  "category": {"custom": {"CRITICAL": 3, "HIGH": 14}}
+//	}
+//
+// category는 정책 카테고리별로 심각도 기한을 덮어쓰며, 기한이 0이면 SLA를 적용하지 않습니다.
+type SLAConfig struct {
+	Severity map[string]int            `json:"severity,omitempty"`
+	Category map[string]map[string]int `json:"category,omitempty"`
+}
+
+// DefaultSLAConfig는 기본 조치 기한을 반환합니다.
+func DefaultSLAConfig() SLAConfig {
+	return SLAConfig{
+		Severity: map[string]int{"CRITICAL": 7, "HIGH": 30, "MEDIUM": 90, "LOW": 180},
+	}
+}
+
+// SLAPolicy는 finding별 조치 기한을 계산합니다.
+type SLAPolicy struct {
+	severity map[string]int
+	category map[string]map[string]int
+}
+
+// NewSLAPolicy는 설정을 기본값과 합쳐 검증하고 SLA 정책을 생성합니다.
+func NewSLAPolicy(config SLAConfig) (*SLAPolicy, error) {
+	policy := &SLAPolicy{
+		severity: DefaultSLAConfig().Severity,
+		category: make(map[string]map[string]int),
+	}
+	for severity, days := range config.Severity {
+		if days < 0 {
+			return nil, fmt.Errorf("invalid SLA for %s: days must be non-negative", severity)
+		}
+		policy.severity[strings.ToUpper(severity)] = days
+	}
+	for category, overrides := range config.Category {
+		policy.category[category] = make(map[string]int)
+		for severity, days := range overrides {
+			if days < 0 {
+				return nil, fmt.Errorf("invalid SLA for %s/%s: days must be non-negative", category, severity)
+			}
+			policy.category[category][strings.ToUpper(severity)] = days
+		}
+	}
+	return policy, nil
+}
+
+// Days는 카테고리/심각도의 조치 기한(일)을 반환합니다 (기한이 없으면 0).
+func (p *SLAPolicy) Days(category, severity string) int {
+	severity = strings.ToUpper(severity)
+	if days, exists := p.category[category][severity]; exists {
+		return days
+	}
+	return p.severity[severity]
+}
+
+// SLAStatus는 finding 1개의 조치 기한과 초과 여부입니다.
+type SLAStatus struct {
+	Days          int    `json:"Days"`          // 조치 기한 (일)
+	FirstSeen     string `json:"FirstSeen"`     // 최초 발견일 (이력이 없으면 스캔 일자)
+	DueDate       string `json:"DueDate"`       // 조치 기한일
+	DaysRemaining int    `json:"DaysRemaining"` // 기준일까지 남은 일수 (초과 시 음수)
+	Overdue       bool   `json:"Overdue"`
+}
+
+// statusInternal은 최초 발견 시각 기준 조치 기한을 계산합니다.
+// 기한이 없거나 최초 발견 시각을 알 수 없으면 nil을 반환합니다.
+func (p *SLAPolicy) statusInternal(category, severity string, firstSeen, asOf time.Time) *SLAStatus {
+	days := p.Days(category, severity)
+	if days <= 0 || firstSeen.IsZero() {
+		return nil
+	}
+
+	// 날짜 단위로 계산 (최초 발견일 + 기한일의 하루 끝까지 기한 내)
+	first := truncateDayInternal(firstSeen)
+	due := first.AddDate(0, 0, days)
+	today := truncateDayInternal(asOf.In(firstSeen.Location()))
+	remaining := int(math.Round(due.Sub(today).Hours() / 24))
+	return &SLAStatus{
+		Days:          days,
+		FirstSeen:     first.Format(slaDateLayout),
+		DueDate:       due.Format(slaDateLayout),
+		DaysRemaining: remaining,
+		Overdue:       remaining < 0,
+	}
+}
+
+func truncateDayInternal(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// firstSeenInternal은 이력의 최초 발견 시각을 반환하며, 이력에 없으면 현재 스캔 시각을 사용합니다.
+func firstSeenInternal(history FindingHistory, fingerprint string, scannedAt time.Time) time.Time {
+	if first, exists := history[fingerprint]; exists && (scannedAt.IsZero() || first.Before(scannedAt)) {
+		return first
+	}
+	return scannedAt
+}
+
+// SLAReport는 기준일 시점의 SLA 초과 finding 목록입니다.
+type SLAReport struct {
+	AsOf            string           `json:"AsOf"`    // 기준일
+	Tracked         int              `json:"Tracked"` // SLA를 적용한 finding 수
+	Total           int              `json:"Total"`   // 기한을 초과한 finding 수
+	SeveritySummary SeveritySummary  `json:"SeveritySummary"`
+	Findings        []OverdueFinding `json:"Findings"`
+}
+
+// OverdueFinding은 기한을 초과한 finding 1개입니다.
+type OverdueFinding struct {
+	Category    string `json:"Category"`
+	Target      string `json:"Target"`
+	PolicyID    string `json:"PolicyID"`
+	Severity    string `json:"Severity"`
+	Title       string `json:"Title"`
+	Resource    string `json:"Resource"`
+	StartLine   int    `json:"StartLine"`
+	Fingerprint string `json:"Fingerprint"`
+	FirstSeen   string `json:"FirstSeen"`
+	DueDate     string `json:"DueDate"`
+	DaysOverdue int    `json:"DaysOverdue"`
+}
+
+// ApplyResults는 Preprocess 결과의 각 Violation에 조치 기한(SLA)을 채우고 기한 초과 목록을 반환합니다.
+// 최초 발견 시각은 history(-baseline 등), 없으면 현재 스캔 시각(scannedAt)을 사용하며 asOf 기준으로 초과 여부를 판단합니다.
+func (p *SLAPolicy) ApplyResults(targetMap map[string]*GroupedTrivyResult, history FindingHistory, scannedAt, asOf time.Time) *SLAReport {
+	report := newSLAReportInternal(asOf)
+	for _, key := range SortedTargetKeys(targetMap) {
+		category, _ := SplitTargetKey(key, targetMap[key])
+		for _, result := range targetMap[key].Results {
+			for _, misconfig := range result.Misconfigurations {
+				for v := range misconfig.Violations {
+					violation := &misconfig.Violations[v]
+					fingerprint := Fingerprint(result.Target, misconfig.ID, violation.Resource)
+					violation.SLA = p.statusInternal(category, misconfig.Severity, firstSeenInternal(history, fingerprint, scannedAt), asOf)
+					report.addInternal(violation.SLA, OverdueFinding{
+						Category:    category,
+						Target:      result.Target,
+						PolicyID:    misconfig.ID,
+						Severity:    misconfig.Severity,
+						Title:       misconfig.Title,
+						Resource:    violation.Resource,
+						StartLine:   violation.StartLine,
+						Fingerprint: fingerprint,
+					})
+				}
+			}
+		}
+	}
+	report.finishInternal()
+	return report
+}
+
+// ApplyExcelData는 Excel 행에 조치 기한을 채우고 기한 초과 목록을 만듭니다.
+func (p *SLAPolicy) ApplyExcelData(data *ExcelData, history FindingHistory, scannedAt, asOf time.Time) {
+	report := newSLAReportInternal(asOf)
+	for c := range data.Categories {
+		category := data.Categories[c].Name
+		rows := data.Categories[c].Rows
+		for i := range rows {
+			row := &rows[i]
+			row.SLA = p.statusInternal(category, row.Severity, firstSeenInternal(history, row.Fingerprint, scannedAt), asOf)
+			report.addInternal(row.SLA, OverdueFinding{
+				Category:    category,
+				Target:      row.Target,
+				PolicyID:    row.PolicyID,
+				Severity:    row.Severity,
+				Title:       row.Title,
+				Resource:    row.Resource,
+				StartLine:   row.StartLine,
+				Fingerprint: row.Fingerprint,
+			})
+		}
+	}
+	report.finishInternal()
+	data.SLA = report
+}
+
+func newSLAReportInternal(asOf time.Time) *SLAReport {
+	return &SLAReport{AsOf: asOf.Format(slaDateLayout), Findings: []OverdueFinding{}}
+}
+
+func (r *SLAReport) addInternal(status *SLAStatus, finding OverdueFinding) {
+	if status == nil {
+		return
+	}
+	r.Tracked++
+	if !status.Overdue {
+		return
+	}
+	finding.FirstSeen = status.FirstSeen
+	finding.DueDate = status.DueDate
+	finding.DaysOverdue = -status.DaysRemaining
+	r.Findings = append(r.Findings, finding)
+	r.SeveritySummary.addSeverity(finding.Severity)
+	r.Total++
+}
+
+// finishInternal은 초과 일수가 큰 순으로 정렬합니다 (같으면 심각도, 타겟, 정책 ID, 라인 순).
+func (r *SLAReport) finishInternal() {
+	sort.SliceStable(r.Findings, func(i, j int) bool {
+		a, b := r.Findings[i], r.Findings[j]
+		if a.DaysOverdue != b.DaysOverdue {
+			return a.DaysOverdue > b.DaysOverdue
+		}
+		if c := compareInt(SeverityRank(a.Severity), SeverityRank(b.Severity)); c != 0 {
+			return c < 0
+		}
+		if a.Target != b.Target {
+			return a.Target < b.Target
+		}
+		if a.PolicyID != b.PolicyID {
+			return a.PolicyID < b.PolicyID
+		}
+		return a.StartLine < b.StartLine
+	})
+}
+
+// CountOverdue는 지정한 심각도의 기한 초과 finding 수를 반환합니다 (severities가 비어 있으면 전체).
+func (r *SLAReport) CountOverdue(severities []string) int {
+	if len(severities) == 0 {
+		return r.Total
+	}
+	count := 0
+	for _, finding := range r.Findings {
+		for _, severity := range severities {
+			if strings.EqualFold(finding.Severity, severity) {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+// ParseSLAGate는 -fail-on-overdue 값("all" 또는 "CRITICAL,HIGH")을 심각도 목록으로 파싱합니다.
+// "all"이면 빈 목록(전체)을 반환합니다.
+func ParseSLAGate(spec string) ([]string, error) {
+	if strings.EqualFold(strings.TrimSpace(spec), "all") {
+		return []string{}, nil
+	}
+	var severities []string
+	for _, severity := range strings.Split(spec, ",") {
+		severity = strings.ToUpper(strings.TrimSpace(severity))
+		switch severity {
+		case "":
+			continue
+		case "CRITICAL", "HIGH", "MEDIUM", "LOW", "UNKNOWN":
+			severities = append(severities, severity)
+		default:
+			return nil, fmt.Errorf("unknown severity in -fail-on-overdue: %q (supported: all, CRITICAL, HIGH, MEDIUM, LOW, UNKNOWN)", severity)
+		}
+	}
+	if len(severities) == 0 {
+		return nil, fmt.Errorf("-fail-on-overdue requires \"all\" or a severity list")
+	}
+	return severities, nil
+}
@@ -0,0 +1,210 @@
+package processor
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSLAStatus(t *testing.T) {
+	policy, err := NewSLAPolicy(SLAConfig{
+		Severity: map[string]int{"low": 0},
+		Category: map[string]map[string]int{
+			CategoryCustom: {"critical": 3, "high": 0},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	date := func(s string, loc *time.Location) time.Time {
+		parsed, err := time.ParseInLocation("2006-01-02 15:04", s, loc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return parsed
+	}
+
+	tests := []struct {
+		name      string
+		category  string
+		severity  string
+		firstSeen time.Time
+		asOf      time.Time
+		want      *SLAStatus
+	}{
+		{
+			name:      "due date is not overdue",
+			category:  CategoryBuiltin,
+			severity:  "CRITICAL",
+			firstSeen: date("2024-01-01 09:00", time.UTC),
+			asOf:      date("2024-01-08 23:59", time.UTC),
+			want:      &SLAStatus{Days: 7, FirstSeen: "2024-01-01", DueDate: "2024-01-08", DaysRemaining: 0},
+		},
+		{
+			name:      "one day past due is overdue",
+			category:  CategoryBuiltin,
+			severity:  "critical",
+			firstSeen: date("2024-01-01 23:59", time.UTC),
+			asOf:      date("2024-01-09 00:00", time.UTC),
+			want:      &SLAStatus{Days: 7, FirstSeen: "2024-01-01", DueDate: "2024-01-08", DaysRemaining: -1, Overdue: true},
+		},
+		{
+			name:      "category override",
+			category:  CategoryCustom,
+			severity:  "CRITICAL",
+			firstSeen: date("2024-01-01 00:00", time.UTC),
+			asOf:      date("2024-01-02 00:00", time.UTC),
+			want:      &SLAStatus{Days: 3, FirstSeen: "2024-01-01", DueDate: "2024-01-04", DaysRemaining: 2},
+		},
+		{
+			name:      "category override of zero disables SLA",
+			category:  CategoryCustom,
+			severity:  "HIGH",
+			firstSeen: date("2024-01-01 00:00", time.UTC),
+			asOf:      date("2025-01-01 00:00", time.UTC),
+		},
+		{
+			name:      "severity of zero disables SLA",
+			category:  CategoryBuiltin,
+			severity:  "LOW",
+			firstSeen: date("2024-01-01 00:00", time.UTC),
+			asOf:      date("2025-01-01 00:00", time.UTC),
+		},
+		{
+			name:     "unknown first seen",
+			category: CategoryBuiltin,
+			severity: "HIGH",
+			asOf:     date("2024-01-01 00:00", time.UTC),
+		},
+		{
+			name:      "as-of is compared in the first seen time zone",
+			category:  CategoryBuiltin,
+			severity:  "CRITICAL",
+			firstSeen: date("2024-01-01 20:00", newYork),
+			asOf:      date("2024-01-09 03:00", time.UTC), // 뉴욕 기준 1월 8일
+			want:      &SLAStatus{Days: 7, FirstSeen: "2024-01-01", DueDate: "2024-01-08", DaysRemaining: 0},
+		},
+		{
+			name:      "DST transition counts calendar days",
+			category:  CategoryBuiltin,
+			severity:  "CRITICAL",
+			firstSeen: date("2024-03-05 12:00", newYork),
+			asOf:      date("2024-03-14 12:00", newYork),
+			want:      &SLAStatus{Days: 7, FirstSeen: "2024-03-05", DueDate: "2024-03-12", DaysRemaining: -2, Overdue: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := policy.statusInternal(tt.category, tt.severity, tt.firstSeen, tt.asOf)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("status = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSLAPolicyInvalid(t *testing.T) {
+	configs := []SLAConfig{
+		{Severity: map[string]int{"HIGH": -1}},
+		{Category: map[string]map[string]int{CategoryCustom: {"LOW": -7}}},
+	}
+	for _, config := range configs {
+		if _, err := NewSLAPolicy(config); err == nil {
+			t.Errorf("NewSLAPolicy(%+v): expected error", config)
+		}
+	}
+}
+
+func TestFirstSeen(t *testing.T) {
+	scannedAt := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	earlier := scannedAt.AddDate(0, -1, 0)
+	later := scannedAt.AddDate(0, 0, 1)
+	history := FindingHistory{"old": earlier, "future": later}
+
+	tests := []struct {
+		fingerprint string
+		scannedAt   time.Time
+		want        time.Time
+	}{
+		{fingerprint: "old", scannedAt: scannedAt, want: earlier},
+		{fingerprint: "missing", scannedAt: scannedAt, want: scannedAt},
+		{fingerprint: "future", scannedAt: scannedAt, want: scannedAt},
+		{fingerprint: "future", want: later},
+		{fingerprint: "missing"},
+	}
+	for _, tt := range tests {
+		if got := firstSeenInternal(history, tt.fingerprint, tt.scannedAt); !got.Equal(tt.want) {
+			t.Errorf("firstSeenInternal(%q, %v) = %v, want %v", tt.fingerprint, tt.scannedAt, got, tt.want)
+		}
+	}
+}
+
+func TestParseSLAGate(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    []string
+		wantErr bool
+	}{
+		{spec: "all", want: []string{}},
+		{spec: " ALL ", want: []string{}},
+		{spec: "high, critical", want: []string{"HIGH", "CRITICAL"}},
+		{spec: "LOW,,", want: []string{"LOW"}},
+		{spec: "", wantErr: true},
+		{spec: " , ", wantErr: true},
+		{spec: "HIGH,severe", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseSLAGate(tt.spec)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSLAGate(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseSLAGate(%q) = %#v, want %#v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestCountOverdue(t *testing.T) {
+	report := newSLAReportInternal(time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC))
+	report.addInternal(nil, OverdueFinding{Severity: "CRITICAL"})
+	report.addInternal(&SLAStatus{DaysRemaining: 3}, OverdueFinding{Severity: "CRITICAL"})
+	report.addInternal(&SLAStatus{DaysRemaining: -1, Overdue: true}, OverdueFinding{Severity: "HIGH", Target: "b.tf"})
+	report.addInternal(&SLAStatus{DaysRemaining: -5, Overdue: true}, OverdueFinding{Severity: "critical", Target: "a.tf"})
+	report.addInternal(&SLAStatus{DaysRemaining: -1, Overdue: true}, OverdueFinding{Severity: "LOW", Target: "a.tf"})
+	report.finishInternal()
+
+	if report.Tracked != 4 || report.Total != 3 {
+		t.Errorf("Tracked = %d, Total = %d", report.Tracked, report.Total)
+	}
+	var order []string
+	for _, finding := range report.Findings {
+		order = append(order, finding.Target)
+	}
+	if want := []string{"a.tf", "b.tf", "a.tf"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+	if report.Findings[0].DaysOverdue != 5 {
+		t.Errorf("DaysOverdue = %d, want 5", report.Findings[0].DaysOverdue)
+	}
+
+	tests := []struct {
+		severities []string
+		want       int
+	}{
+		{severities: []string{}, want: 3},
+		{severities: nil, want: 3},
+		{severities: []string{"CRITICAL"}, want: 1},
+		{severities: []string{"CRITICAL", "HIGH"}, want: 2},
+		{severities: []string{"MEDIUM"}, want: 0},
+	}
+	for _, tt := range tests {
+		if got := report.CountOverdue(tt.severities); got != tt.want {
+			t.Errorf("CountOverdue(%v) = %d, want %d", tt.severities, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package processor
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ansiEscapePattern은 터미널 색상 코드(ANSI escape sequence)를 매칭합니다.
+var ansiEscapePattern = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+// StripANSI는 문자열에서 ANSI escape sequence를 제거합니다.
+func StripANSI(s string) string {
+	return ansiEscapePattern.ReplaceAllString(s, "")
+}
+
+// FormatCodeSnippet은 CodeBlock을 사람이 읽을 수 있는 일반 텍스트로 변환합니다.
+// 원인(IsCause) 라인은 ">" 로 표시하며, 잘린 라인은 "..." 을 덧붙입니다.
+// 예: "> 45 |   encrypted = false"
+func FormatCodeSnippet(code *CodeBlock) string {
+	if code == nil || len(code.Lines) == 0 {
+		return ""
+	}
+
+	// 라인 번호 폭 계산 (정렬용)
+	width := 0
+	for _, line := range code.Lines {
+		if w := len(fmt.Sprint(line.Number)); w > width {
+			width = w
+		}
+	}
+
+	var b strings.Builder
+	for i, line := range code.Lines {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+
+		// Content가 비어있으면 Highlighted에서 색상 코드를 제거해 사용
+		content := line.Content
+		if content == "" && line.Highlighted != "" {
+			content = line.Highlighted
+		}
+		content = StripANSI(content)
+
+		marker := " "
+		if line.IsCause {
+			marker = ">"
+		}
+		fmt.Fprintf(&b, "%s %*d | %s", marker, width, line.Number, content)
+		if line.Truncated {
+			b.WriteString(" ...")
+		}
+	}
+
+	return b.String()
+}
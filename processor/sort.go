@@ -0,0 +1,205 @@
+package processor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SortKey는 출력 정렬 기준입니다.
+type SortKey struct {
+	Field string
+	Desc  bool
+}
+
+// 지원하는 정렬 기준
+const (
+	SortBySeverity = "severity"
+	SortByPolicy   = "policy"
+	SortByTarget   = "target"
+	SortByLine     = "line"
+	SortByRisk     = "risk" // 위험 점수가 높은 순 (-risk 옵션 사용 시)
+)
+
+// ParseSortKeys는 "severity,policy,-line" 형식의 정렬 기준 문자열을 파싱합니다.
+// "-" 접두사는 역순 정렬을 의미합니다.
+func ParseSortKeys(spec string) ([]SortKey, error) {
+	var keys []SortKey
+	for _, field := range strings.Split(spec, ",") {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if field == "" {
+			continue
+		}
+
+		key := SortKey{}
+		if strings.HasPrefix(field, "-") {
+			key.Desc = true
+			field = field[1:]
+		}
+
+		switch field {
+		case SortBySeverity, SortByPolicy, SortByTarget, SortByLine, SortByRisk:
+			key.Field = field
+		default:
+			return nil, fmt.Errorf("unknown sort key: %q (supported: severity, policy, target, line, risk)", field)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// SeverityRank는 심각도의 정렬 순위를 반환합니다 (CRITICAL이 0으로 가장 높음).
+func SeverityRank(severity string) int {
+	switch strings.ToUpper(severity) {
+	case "CRITICAL":
+		return 0
+	case "HIGH":
+		return 1
+	case "MEDIUM":
+		return 2
+	case "LOW":
+		return 3
+	default:
+		return 4
+	}
+}
+
+// SortedTargetKeys는 타겟 맵의 키를 사전순으로 정렬하여 반환합니다.
+func SortedTargetKeys(targetMap map[string]*GroupedTrivyResult) []string {
+	keys := make([]string, 0, len(targetMap))
+	for key := range targetMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// SortGroupedResult는 그룹화된 결과를 정렬 기준에 따라 정렬합니다.
+// Results는 target, Misconfigurations는 severity/policy/line/risk, Violations는 line/risk 기준을 사용합니다.
+func SortGroupedResult(result *GroupedTrivyResult, keys []SortKey) {
+	if len(keys) == 0 {
+		return
+	}
+
+	sort.SliceStable(result.Results, func(i, j int) bool {
+		return compareByKeysInternal(keys, func(field string) int {
+			if field == SortByTarget {
+				return strings.Compare(result.Results[i].Target, result.Results[j].Target)
+			}
+			return 0
+		}) < 0
+	})
+
+	for r := range result.Results {
+		misconfigs := result.Results[r].Misconfigurations
+		for m := range misconfigs {
+			violations := misconfigs[m].Violations
+			sort.SliceStable(violations, func(i, j int) bool {
+				return compareByKeysInternal(keys, func(field string) int {
+					switch field {
+					case SortByLine:
+						return compareInt(violations[i].StartLine, violations[j].StartLine)
+					case SortByRisk:
+						return compareRiskInternal(violations[i].Risk, violations[j].Risk)
+					}
+					return 0
+				}) < 0
+			})
+		}
+
+		sort.SliceStable(misconfigs, func(i, j int) bool {
+			a, b := misconfigs[i], misconfigs[j]
+			return compareByKeysInternal(keys, func(field string) int {
+				switch field {
+				case SortBySeverity:
+					return compareInt(SeverityRank(a.Severity), SeverityRank(b.Severity))
+				case SortByPolicy:
+					return strings.Compare(a.ID, b.ID)
+				case SortByLine:
+					return compareInt(firstLineInternal(a.Violations), firstLineInternal(b.Violations))
+				case SortByRisk:
+					return compareFloatInternal(maxRiskScoreInternal(b), maxRiskScoreInternal(a))
+				}
+				return 0
+			}) < 0
+		})
+	}
+}
+
+// SortExcelData는 Excel 시트의 행들을 정렬 기준에 따라 정렬합니다.
+func SortExcelData(data *ExcelData, keys []SortKey) {
+	for _, category := range data.Categories {
+		SortExcelRows(category.Rows, keys)
+	}
+}
+
+// SortExcelRows는 Excel 행 목록을 정렬 기준에 따라 정렬합니다.
+func SortExcelRows(rows []ExcelRow, keys []SortKey) {
+	if len(keys) == 0 {
+		return
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		return compareByKeysInternal(keys, func(field string) int {
+			switch field {
+			case SortBySeverity:
+				return compareInt(SeverityRank(a.Severity), SeverityRank(b.Severity))
+			case SortByPolicy:
+				return strings.Compare(a.PolicyID, b.PolicyID)
+			case SortByTarget:
+				return strings.Compare(a.Target, b.Target)
+			case SortByLine:
+				return compareInt(a.StartLine, b.StartLine)
+			case SortByRisk:
+				return compareRiskInternal(a.Risk, b.Risk)
+			}
+			return 0
+		}) < 0
+	})
+}
+
+// compareByKeysInternal은 정렬 기준을 순서대로 적용하여 첫 번째로 차이가 나는 비교 결과를 반환합니다.
+func compareByKeysInternal(keys []SortKey, compare func(field string) int) int {
+	for _, key := range keys {
+		if c := compare(key.Field); c != 0 {
+			if key.Desc {
+				return -c
+			}
+			return c
+		}
+	}
+	return 0
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compareRiskInternal은 위험 점수가 높은 쪽을 앞으로 비교합니다 (점수가 없으면 0점).
+func compareRiskInternal(a, b *RiskScore) int {
+	var scoreA, scoreB float64
+	if a != nil {
+		scoreA = a.Score
+	}
+	if b != nil {
+		scoreB = b.Score
+	}
+	return compareFloatInternal(scoreB, scoreA)
+}
+
+func compareFloatInternal(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,210 @@
+package processor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+)
+
+// 로컬 소스와 스캔 리비전의 일치 여부
+const (
+	SourceMatch      = "match"      // 파일 해시 또는 스캔 코드 라인이 로컬 파일과 일치
+	SourceMismatch   = "mismatch"   // 로컬 파일이 스캔한 리비전과 다름 (라인 번호가 어긋났을 수 있음)
+	SourceUnverified = "unverified" // 비교할 해시나 코드 라인이 없음
+)
+
+// SourceFile은 로컬 체크아웃에서 읽은 타겟 파일입니다.
+type SourceFile struct {
+	Lines  []string
+	SHA256 string // 파일 내용의 SHA-256 (16진수)
+}
+
+// NewSourceFile은 파일 내용으로 SourceFile을 생성합니다.
+func NewSourceFile(content []byte) *SourceFile {
+	sum := sha256.Sum256(content)
+	text := strings.ReplaceAll(string(content), "\r\n", "\n")
+	return &SourceFile{
+		Lines:  strings.Split(strings.TrimSuffix(text, "\n"), "\n"),
+		SHA256: hex.EncodeToString(sum[:]),
+	}
+}
+
+// SourceIndex는 타겟 경로를 키로 하는 로컬 소스 파일 모음입니다.
+type SourceIndex map[string]*SourceFile
+
+// SourceOptions는 로컬 소스 스니펫 첨부 옵션입니다.
+type SourceOptions struct {
+	Context int               // 원인 라인 앞뒤로 포함할 라인 수
+	Hashes  map[string]string // 스캔 시점의 타겟별 SHA-256 (없으면 스캔 코드 라인과 비교)
+}
+
+// SourceSnippet은 로컬 체크아웃에서 읽은 원인 라인 주변 코드입니다.
+type SourceSnippet struct {
+	StartLine int    `json:"StartLine"` // 컨텍스트를 포함한 시작 라인
+	EndLine   int    `json:"EndLine"`   // 컨텍스트를 포함한 끝 라인
+	Code      string `json:"Code"`      // 원인 라인이 ">"로 표시된 텍스트
+	SHA256    string `json:"SHA256"`    // 로컬 파일의 SHA-256
+	Status    string `json:"Status"`    // match, mismatch, unverified
+}
+
+// SourceStats는 스니펫 첨부 결과 집계입니다.
+type SourceStats struct {
+	Attached   int      // 스니펫을 첨부한 Violation 수
+	Mismatched []string // 로컬 파일이 스캔 리비전과 다른 타겟 목록
+	Missing    []string // 로컬에서 찾을 수 없는 타겟 목록
+}
+
+// ResultTargets는 Preprocess 결과에 포함된 타겟 경로를 정렬하여 반환합니다.
+func ResultTargets(targetMap map[string]*GroupedTrivyResult) []string {
+	targets := make(map[string]bool)
+	for _, targetResult := range targetMap {
+		for _, result := range targetResult.Results {
+			targets[result.Target] = true
+		}
+	}
+	return sortedSetInternal(targets)
+}
+
+// AttachSources는 Preprocess 결과의 각 Violation에 로컬 소스 스니펫(Source)을 첨부합니다.
+// 스캔 결과의 Code가 없거나 잘린 경우, 로컬 파일이 스캔 리비전과 다르지 않으면 Code도 로컬 라인으로 채웁니다.
+func AttachSources(targetMap map[string]*GroupedTrivyResult, sources SourceIndex, opts SourceOptions) SourceStats {
+	var stats SourceStats
+	mismatched := make(map[string]bool)
+	missing := make(map[string]bool)
+
+	for _, key := range SortedTargetKeys(targetMap) {
+		for r := range targetMap[key].Results {
+			result := &targetMap[key].Results[r]
+			file, exists := sources[result.Target]
+			if !exists {
+				missing[result.Target] = true
+				continue
+			}
+
+			for i := range result.Misconfigurations {
+				violations := result.Misconfigurations[i].Violations
+				for v := range violations {
+					violation := &violations[v]
+					// 파일 단위 finding (StartLine 0) 등 라인 범위가 없으면 비교할 수 없으므로 건너뜀
+					if violation.StartLine < 1 || violation.EndLine < violation.StartLine {
+						continue
+					}
+					// 라인 범위가 로컬 파일 끝을 벗어나면 스캔 리비전과 다른 파일
+					snippet, block := file.snippetInternal(violation.StartLine, violation.EndLine, opts.Context)
+					if snippet == nil {
+						mismatched[result.Target] = true
+						continue
+					}
+
+					snippet.Status = file.verifyInternal(violation.Code, opts.Hashes, result.Target)
+					if snippet.Status == SourceMismatch {
+						mismatched[result.Target] = true
+					} else if codeIncompleteInternal(violation.Code) {
+						violation.Code = block
+					}
+					violation.Source = snippet
+					stats.Attached++
+				}
+			}
+		}
+	}
+
+	stats.Mismatched = sortedSetInternal(mismatched)
+	stats.Missing = sortedSetInternal(missing)
+	return stats
+}
+
+// snippetInternal은 startLine~endLine 주변 context 라인을 잘라 스니펫과 CodeBlock을 만듭니다.
+// 라인 범위가 유효하지 않거나 파일을 벗어나면 nil을 반환합니다.
+func (f *SourceFile) snippetInternal(startLine, endLine, context int) (*SourceSnippet, *CodeBlock) {
+	if startLine < 1 || endLine < startLine || endLine > len(f.Lines) {
+		return nil, nil
+	}
+
+	from := startLine - context
+	if from < 1 {
+		from = 1
+	}
+	to := endLine + context
+	if to > len(f.Lines) {
+		to = len(f.Lines)
+	}
+
+	block := &CodeBlock{}
+	for number := from; number <= to; number++ {
+		block.Lines = append(block.Lines, CodeLine{
+			Number:     number,
+			Content:    f.Lines[number-1],
+			IsCause:    number >= startLine && number <= endLine,
+			FirstCause: number == startLine,
+			LastCause:  number == endLine,
+		})
+	}
+
+	return &SourceSnippet{
+		StartLine: from,
+		EndLine:   to,
+		Code:      FormatCodeSnippet(block),
+		SHA256:    f.SHA256,
+	}, block
+}
+
+// verifyInternal은 로컬 파일이 스캔한 리비전과 같은지 확인합니다.
+// 스캔 시점 해시가 있으면 해시로, 없으면 스캔 결과의 코드 라인(잘리지 않은 라인)과 비교합니다.
+func (f *SourceFile) verifyInternal(code *CodeBlock, hashes map[string]string, target string) string {
+	if expected, exists := hashes[target]; exists {
+		if strings.EqualFold(expected, f.SHA256) {
+			return SourceMatch
+		}
+		return SourceMismatch
+	}
+
+	if code == nil {
+		return SourceUnverified
+	}
+	compared := 0
+	for _, line := range code.Lines {
+		if line.Truncated || line.Number < 1 {
+			continue
+		}
+		if line.Number > len(f.Lines) {
+			return SourceMismatch
+		}
+		content := line.Content
+		if content == "" && line.Highlighted != "" {
+			content = StripANSI(line.Highlighted)
+		}
+		if strings.TrimRight(content, " \t") != strings.TrimRight(f.Lines[line.Number-1], " \t") {
+			return SourceMismatch
+		}
+		compared++
+	}
+	if compared == 0 {
+		return SourceUnverified
+	}
+	return SourceMatch
+}
+
+// codeIncompleteInternal은 스캔 결과의 코드 블록이 없거나 잘렸는지 확인합니다.
+func codeIncompleteInternal(code *CodeBlock) bool {
+	if code == nil || len(code.Lines) == 0 {
+		return true
+	}
+	for _, line := range code.Lines {
+		if line.Truncated {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedSetInternal은 집합의 키를 정렬하여 반환합니다 (비어 있으면 nil).
+func sortedSetInternal(set map[string]bool) []string {
+	var keys []string
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
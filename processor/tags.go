@@ -0,0 +1,258 @@
+package processor
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Untagged는 리소스에 해당 태그가 없을 때의 태그 값입니다.
+const Untagged = "untagged"
+
+// DefaultTagKeys는 Excel 태그 컬럼과 Tags 시트에 기본으로 사용하는 태그 키입니다.
+var DefaultTagKeys = []string{"Owner", "Environment", "DataClassification"}
+
+// TagCondition은 태그 조건 1개입니다. Pattern은 path.Match 형식입니다.
+type TagCondition struct {
+	Key     string
+	Pattern string
+}
+
+// TagWeight는 태그 조건이 일치할 때 곱하는 위험 가중치입니다.
+type TagWeight struct {
+	TagCondition
+	Weight float64
+}
+
+// TerraformOptions는 Terraform 리소스 태그 활용 옵션입니다.
+type TerraformOptions struct {
+	Filter  []TagCondition // 모든 조건이 일치하는 finding만 남김 (비어 있으면 필터 안 함)
+	Weights []TagWeight    // 일치하는 가중치를 모두 곱함 (비어 있으면 가중치 없음)
+	Keys    []string       // Excel 태그 컬럼 및 Tags 시트의 태그 키
+}
+
+// ParseTagFilter는 "Environment=prod,Owner=team-*" 형식의 태그 필터를 파싱합니다.
+func ParseTagFilter(spec string) ([]TagCondition, error) {
+	var conditions []TagCondition
+	for _, part := range strings.Split(spec, ",") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		condition, err := parseTagConditionInternal(part)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions, nil
+}
+
+// ParseTagWeights는 "Environment=prod:2,DataClassification=confidential:1.5" 형식의 태그 가중치를 파싱합니다.
+func ParseTagWeights(spec string) ([]TagWeight, error) {
+	var weights []TagWeight
+	for _, part := range strings.Split(spec, ",") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		i := strings.LastIndex(part, ":")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid tag weight %q: expected <key>=<value>:<weight>", part)
+		}
+		weight, err := strconv.ParseFloat(part[i+1:], 64)
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("invalid tag weight %q: weight must be a non-negative number", part)
+		}
+		condition, err := parseTagConditionInternal(part[:i])
+		if err != nil {
+			return nil, err
+		}
+		weights = append(weights, TagWeight{TagCondition: condition, Weight: weight})
+	}
+	return weights, nil
+}
+
+func parseTagConditionInternal(s string) (TagCondition, error) {
+	key, pattern, found := strings.Cut(s, "=")
+	key, pattern = strings.TrimSpace(key), strings.TrimSpace(pattern)
+	if !found || key == "" {
+		return TagCondition{}, fmt.Errorf("invalid tag condition %q: expected <key>=<value>", s)
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return TagCondition{}, fmt.Errorf("invalid tag pattern %q: %w", pattern, err)
+	}
+	return TagCondition{Key: key, Pattern: pattern}, nil
+}
+
+// Match는 태그가 조건과 일치하는지 확인합니다. 태그가 없으면 일치하지 않습니다.
+func (c TagCondition) Match(tags map[string]string) bool {
+	value, exists := tags[c.Key]
+	if !exists {
+		return false
+	}
+	ok, _ := path.Match(c.Pattern, value)
+	return ok
+}
+
+// matchAllTagsInternal은 모든 조건이 일치하는지 확인합니다.
+func matchAllTagsInternal(conditions []TagCondition, tags map[string]string) bool {
+	for _, condition := range conditions {
+		if !condition.Match(tags) {
+			return false
+		}
+	}
+	return true
+}
+
+// TagWeightOf는 태그에 일치하는 가중치를 모두 곱한 값을 반환합니다 (일치하는 가중치가 없으면 1).
+func TagWeightOf(weights []TagWeight, tags map[string]string) float64 {
+	weight := 1.0
+	for _, w := range weights {
+		if w.Match(tags) {
+			weight *= w.Weight
+		}
+	}
+	return weight
+}
+
+// TagValue는 리소스 태그 값을 반환합니다 (리소스 정보나 태그가 없으면 Untagged).
+func (v Violation) TagValue(key string) string {
+	if v.Terraform != nil {
+		if value, exists := v.Terraform.Tags[key]; exists && value != "" {
+			return value
+		}
+	}
+	return Untagged
+}
+
+// EnrichResults는 Preprocess 결과의 각 Violation에 리소스 정보(Terraform)와 태그 가중치(RiskWeight)를 채우고,
+// 태그 필터가 있으면 일치하지 않는 finding을 제거합니다 (리소스를 찾지 못한 finding도 제거).
+func (idx *TerraformIndex) EnrichResults(targetMap map[string]*GroupedTrivyResult, opts TerraformOptions) {
+	for key, targetResult := range targetMap {
+		results := targetResult.Results[:0]
+		for _, result := range targetResult.Results {
+			misconfigs := result.Misconfigurations[:0]
+			for _, misconfig := range result.Misconfigurations {
+				violations := misconfig.Violations[:0]
+				for _, violation := range misconfig.Violations {
+					violation.Terraform = idx.Lookup(result.Target, violation.Resource)
+					var tags map[string]string
+					if violation.Terraform != nil {
+						tags = violation.Terraform.Tags
+					}
+					if len(opts.Filter) > 0 && !matchAllTagsInternal(opts.Filter, tags) {
+						continue
+					}
+					if len(opts.Weights) > 0 {
+						violation.RiskWeight = TagWeightOf(opts.Weights, tags)
+					}
+					violations = append(violations, violation)
+				}
+				if len(violations) > 0 {
+					misconfig.Violations = violations
+					misconfigs = append(misconfigs, misconfig)
+				}
+			}
+			if len(misconfigs) > 0 {
+				result.Misconfigurations = misconfigs
+				result.MisconfSummary.Failures = len(misconfigs)
+				results = append(results, result)
+			}
+		}
+
+		if len(results) == 0 {
+			delete(targetMap, key)
+			continue
+		}
+		targetResult.Results = results
+		calculateSeveritySummaryInternal(targetResult)
+	}
+}
+
+// EnrichExcelData는 Excel 행에 리소스 태그, 모듈 디렉터리, 태그 가중치를 채우고,
+// 태그 필터가 있으면 일치하지 않는 행을 제거한 뒤 태그별 집계(Tags 시트)를 만듭니다.
+func (idx *TerraformIndex) EnrichExcelData(data *ExcelData, opts TerraformOptions) {
+	data.TagKeys = opts.Keys
+	if data.TagKeys == nil {
+		data.TagKeys = []string{}
+	}
+	data.RiskWeights = len(opts.Weights) > 0
+
+	for c := range data.Categories {
+		rows := data.Categories[c].Rows[:0]
+		for _, row := range data.Categories[c].Rows {
+			if resource := idx.Lookup(row.Target, row.Resource); resource != nil {
+				row.Tags = resource.Tags
+				row.ModuleDir = resource.ModuleDir
+			}
+			if len(opts.Filter) > 0 && !matchAllTagsInternal(opts.Filter, row.Tags) {
+				continue
+			}
+			row.RiskWeight = TagWeightOf(opts.Weights, row.Tags)
+			rows = append(rows, row)
+		}
+		data.Categories[c].Rows = rows
+	}
+
+	// 필터로 행이 줄었을 수 있으므로 모듈 집계를 다시 계산
+	data.Modules = SummarizeModules(data)
+	data.TagSummary = SummarizeTags(data, data.TagKeys)
+}
+
+// TagSummary는 태그 키/값 1개의 finding 집계입니다.
+type TagSummary struct {
+	Key      string
+	Value    string
+	Severity SeveritySummary
+	Total    int
+}
+
+// SummarizeTags는 Excel 행을 태그 키/값별로 집계합니다.
+// 키는 keys 순서, 값은 이름 순이며 태그가 없는 행은 Untagged로 집계합니다.
+func SummarizeTags(data *ExcelData, keys []string) []TagSummary {
+	var summaries []TagSummary
+	for _, key := range keys {
+		byValue := make(map[string]*TagSummary)
+		for _, category := range data.Categories {
+			for _, row := range category.Rows {
+				value := row.Tags[key]
+				if value == "" {
+					value = Untagged
+				}
+				summary, exists := byValue[value]
+				if !exists {
+					summary = &TagSummary{Key: key, Value: value}
+					byValue[value] = summary
+				}
+				summary.Severity.addSeverity(row.Severity)
+				summary.Total++
+			}
+		}
+
+		values := make([]string, 0, len(byValue))
+		for value := range byValue {
+			values = append(values, value)
+		}
+		sort.Strings(values)
+		for _, value := range values {
+			summaries = append(summaries, *byValue[value])
+		}
+	}
+	return summaries
+}
+
+// FormatTags는 태그를 "key=value; ..." 형식의 한 줄 텍스트로 만듭니다 (키 이름 순).
+func FormatTags(tags map[string]string) string {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, key+"="+tags[key])
+	}
+	return strings.Join(parts, "; ")
+}
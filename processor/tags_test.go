@@ -0,0 +1,167 @@
+package processor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTagFilter(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    []TagCondition
+		wantErr bool
+	}{
+		{spec: ""},
+		{spec: "Environment=prod", want: []TagCondition{{Key: "Environment", Pattern: "prod"}}},
+		{spec: " Environment = prod , Owner=team-* ,", want: []TagCondition{{Key: "Environment", Pattern: "prod"}, {Key: "Owner", Pattern: "team-*"}}},
+		{spec: "Owner=", want: []TagCondition{{Key: "Owner", Pattern: ""}}},
+		{spec: "Environment", wantErr: true},
+		{spec: "=prod", wantErr: true},
+		{spec: "Owner=team-[", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseTagFilter(tt.spec)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseTagFilter(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseTagFilter(%q) = %+v, want %+v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestParseTagWeights(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    []TagWeight
+		wantErr bool
+	}{
+		{spec: ""},
+		{
+			spec: "Environment=prod:2, DataClassification=confidential:1.5",
+			want: []TagWeight{
+				{TagCondition: TagCondition{Key: "Environment", Pattern: "prod"}, Weight: 2},
+				{TagCondition: TagCondition{Key: "DataClassification", Pattern: "confidential"}, Weight: 1.5},
+			},
+		},
+		{spec: "Endpoint=http://*:0", want: []TagWeight{{TagCondition: TagCondition{Key: "Endpoint", Pattern: "http://*"}, Weight: 0}}},
+		{spec: "Environment=prod", wantErr: true},
+		{spec: "Environment=prod:high", wantErr: true},
+		{spec: "Environment=prod:-1", wantErr: true},
+		{spec: "prod:2", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseTagWeights(tt.spec)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseTagWeights(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseTagWeights(%q) = %+v, want %+v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestTagWeightOf(t *testing.T) {
+	weights, err := ParseTagWeights("Environment=prod:2,Owner=team-*:1.5,Environment=*:3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		tags map[string]string
+		want float64
+	}{
+		{tags: nil, want: 1},
+		{tags: map[string]string{"Owner": "team-a"}, want: 1.5},
+		{tags: map[string]string{"Environment": "prod", "Owner": "team-a"}, want: 9},
+		{tags: map[string]string{"Environment": "dev"}, want: 3},
+	}
+	for _, tt := range tests {
+		if got := TagWeightOf(weights, tt.tags); got != tt.want {
+			t.Errorf("TagWeightOf(%v) = %v, want %v", tt.tags, got, tt.want)
+		}
+	}
+}
+
+func TestEnrichResults(t *testing.T) {
+	index := ParseTerraformFiles(terraformTestFiles)
+	filter, err := ParseTagFilter("Environment=prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	weights, err := ParseTagWeights("Team=core:2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	violation := func(resource string) Violation { return Violation{Resource: resource} }
+	targetMap := map[string]*GroupedTrivyResult{
+		"main.tf": {
+			Results: []GroupedResult{{
+				Target:         "main.tf",
+				MisconfSummary: MisconfSummary{Successes: 4, Failures: 3},
+				Misconfigurations: []GroupedMisconfiguration{
+					{ID: "AVD-1", Severity: "HIGH", Violations: []Violation{violation("aws_s3_bucket.logs"), violation("aws_s3_bucket.missing")}},
+					{ID: "AVD-2", Severity: "CRITICAL", Violations: []Violation{violation("aws_s3_bucket.missing")}},
+					{ID: "AVD-3", Severity: "LOW", Violations: []Violation{violation("aws_instance.loop")}},
+				},
+			}},
+		},
+		"modules/app/main.tf": {
+			Results: []GroupedResult{{
+				Target:            "modules/app/main.tf",
+				Misconfigurations: []GroupedMisconfiguration{{ID: "AVD-1", Severity: "HIGH", Violations: []Violation{violation("aws_lb.public")}}},
+			}},
+		},
+	}
+
+	index.EnrichResults(targetMap, TerraformOptions{Filter: filter, Weights: weights})
+
+	if _, exists := targetMap["modules/app/main.tf"]; exists {
+		t.Error("target without matching findings should be removed")
+	}
+	result := targetMap["main.tf"]
+	if result == nil || len(result.Results) != 1 {
+		t.Fatalf("main.tf = %+v", result)
+	}
+	misconfigs := result.Results[0].Misconfigurations
+	if len(misconfigs) != 1 || misconfigs[0].ID != "AVD-1" || len(misconfigs[0].Violations) != 1 {
+		t.Fatalf("Misconfigurations = %+v", misconfigs)
+	}
+	got := misconfigs[0].Violations[0]
+	if got.Terraform == nil || got.Terraform.Address != "aws_s3_bucket.logs" || got.RiskWeight != 2 {
+		t.Errorf("Violation = %+v", got)
+	}
+	if got.TagValue("Owner") != "platform" || got.TagValue("DataClassification") != Untagged {
+		t.Errorf("TagValue = %q, %q", got.TagValue("Owner"), got.TagValue("DataClassification"))
+	}
+	if summary := result.Results[0].MisconfSummary; summary != (MisconfSummary{Successes: 4, Failures: 1}) {
+		t.Errorf("MisconfSummary = %+v", summary)
+	}
+	if result.SeveritySummary == nil || *result.SeveritySummary != (SeveritySummary{High: 1}) {
+		t.Errorf("SeveritySummary = %+v", result.SeveritySummary)
+	}
+}
+
+func TestEnrichResultsWithoutFilter(t *testing.T) {
+	index := ParseTerraformFiles(terraformTestFiles)
+	targetMap := map[string]*GroupedTrivyResult{
+		"main.tf": {
+			Results: []GroupedResult{{
+				Target:            "main.tf",
+				Misconfigurations: []GroupedMisconfiguration{{ID: "AVD-2", Severity: "CRITICAL", Violations: []Violation{{Resource: "aws_s3_bucket.missing"}}}},
+			}},
+		},
+	}
+
+	index.EnrichResults(targetMap, TerraformOptions{})
+
+	violation := targetMap["main.tf"].Results[0].Misconfigurations[0].Violations[0]
+	if violation.Terraform != nil || violation.RiskWeight != 0 || violation.TagValue("Owner") != Untagged {
+		t.Errorf("Violation = %+v", violation)
+	}
+	if *targetMap["main.tf"].SeveritySummary != (SeveritySummary{Critical: 1}) {
+		t.Errorf("SeveritySummary = %+v", targetMap["main.tf"].SeveritySummary)
+	}
+}
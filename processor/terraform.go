@@ -0,0 +1,277 @@
+package processor
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// TerraformResource는 로컬 .tf 파일에서 파싱한 리소스 블록 정보입니다.
+type TerraformResource struct {
+	Address   string            `json:"Address"`   // 예: aws_s3_bucket.logs
+	File      string            `json:"File"`      // 루트 기준 파일 경로
+	ModuleDir string            `json:"ModuleDir"` // 리소스를 선언한 모듈 디렉터리 (루트 모듈이면 ".")
+	StartLine int               `json:"StartLine"`
+	EndLine   int               `json:"EndLine"`
+	Tags      map[string]string `json:"Tags,omitempty"` // provider default_tags와 tags를 합친 값
+}
+
+// TerraformIndex는 파일/주소별 리소스 목록입니다.
+type TerraformIndex struct {
+	byFile map[string]map[string]*TerraformResource // 파일 -> 주소 -> 리소스
+	byDir  map[string]map[string]*TerraformResource // 모듈 디렉터리 -> 주소 -> 리소스
+
+	// Skipped는 구문 오류로 건너뛴 파일의 오류 목록입니다.
+	Skipped []error
+}
+
+// terraformModuleInternal은 모듈 디렉터리 1개의 파싱 상태입니다.
+type terraformModuleInternal struct {
+	locals      map[string]hclsyntax.Expression
+	variables   map[string]hclsyntax.Expression // variable 블록의 default
+	defaultTags map[string]hclsyntax.Expression // provider 이름 -> default_tags.tags
+	resources   []terraformResourceBlockInternal
+	sources     map[string][]byte // 파일 -> 내용 (표현식 원문 추출용)
+}
+
+type terraformResourceBlockInternal struct {
+	resource *TerraformResource
+	provider string
+	tags     hclsyntax.Expression
+}
+
+// maxTagDepth는 local/var 참조를 따라가는 최대 깊이입니다 (순환 참조 방지).
+const maxTagDepth = 8
+
+// ParseTerraformFiles는 .tf 파일들(키: 루트 기준 경로)을 파싱하여 리소스 인덱스를 만듭니다.
+// tags는 리터럴, merge(), 같은 모듈의 local.*/var.*(default) 참조를 해석하며,
+// 해석할 수 없는 값은 표현식 원문(예: "${var.env}")으로 기록합니다.
+// 구문 오류가 있는 파일은 건너뛰고 Skipped에 기록합니다.
+func ParseTerraformFiles(files map[string][]byte) *TerraformIndex {
+	modules := make(map[string]*terraformModuleInternal)
+	var skipped []error
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		file, diags := hclsyntax.ParseConfig(files[name], name, hcl.Pos{Line: 1, Column: 1})
+		if diags.HasErrors() {
+			skipped = append(skipped, fmt.Errorf("%s: %s", name, diags.Error()))
+			continue
+		}
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		dir := path.Dir(name)
+		module, exists := modules[dir]
+		if !exists {
+			module = &terraformModuleInternal{
+				locals:      make(map[string]hclsyntax.Expression),
+				variables:   make(map[string]hclsyntax.Expression),
+				defaultTags: make(map[string]hclsyntax.Expression),
+				sources:     make(map[string][]byte),
+			}
+			modules[dir] = module
+		}
+		module.sources[name] = files[name]
+		module.collectInternal(name, dir, body)
+	}
+
+	index := &TerraformIndex{
+		byFile:  make(map[string]map[string]*TerraformResource),
+		byDir:   make(map[string]map[string]*TerraformResource),
+		Skipped: skipped,
+	}
+	for dir, module := range modules {
+		for _, block := range module.resources {
+			tags := make(map[string]string)
+			if expr, exists := module.defaultTags[block.provider]; exists {
+				module.evalTagsInternal(expr, tags, 0)
+			}
+			if block.tags != nil {
+				module.evalTagsInternal(block.tags, tags, 0)
+			}
+			if len(tags) > 0 {
+				block.resource.Tags = tags
+			}
+
+			resource := block.resource
+			if index.byFile[resource.File] == nil {
+				index.byFile[resource.File] = make(map[string]*TerraformResource)
+			}
+			if index.byDir[dir] == nil {
+				index.byDir[dir] = make(map[string]*TerraformResource)
+			}
+			index.byFile[resource.File][resource.Address] = resource
+			index.byDir[dir][resource.Address] = resource
+		}
+	}
+	return index
+}
+
+// collectInternal은 파일 1개에서 resource, locals, variable, provider default_tags 블록을 모읍니다.
+func (m *terraformModuleInternal) collectInternal(name, dir string, body *hclsyntax.Body) {
+	for _, block := range body.Blocks {
+		switch {
+		case block.Type == "resource" && len(block.Labels) == 2:
+			resource := &TerraformResource{
+				Address:   block.Labels[0] + "." + block.Labels[1],
+				File:      name,
+				ModuleDir: dir,
+				StartLine: block.Range().Start.Line,
+				EndLine:   block.Range().End.Line,
+			}
+			provider, _, _ := strings.Cut(block.Labels[0], "_")
+			if attr, exists := block.Body.Attributes["provider"]; exists {
+				// provider = aws.seoul 형식의 별칭은 provider 이름만 사용
+				if traversal, ok := attr.Expr.(*hclsyntax.ScopeTraversalExpr); ok {
+					provider = traversal.Traversal.RootName()
+				}
+			}
+			entry := terraformResourceBlockInternal{resource: resource, provider: provider}
+			if attr, exists := block.Body.Attributes["tags"]; exists {
+				entry.tags = attr.Expr
+			}
+			m.resources = append(m.resources, entry)
+
+		case block.Type == "locals":
+			for key, attr := range block.Body.Attributes {
+				m.locals[key] = attr.Expr
+			}
+
+		case block.Type == "variable" && len(block.Labels) == 1:
+			if attr, exists := block.Body.Attributes["default"]; exists {
+				m.variables[block.Labels[0]] = attr.Expr
+			}
+
+		case block.Type == "provider" && len(block.Labels) == 1:
+			// 별칭(alias) provider는 기본 provider의 default_tags를 덮어쓰지 않음
+			if _, aliased := block.Body.Attributes["alias"]; aliased {
+				continue
+			}
+			for _, nested := range block.Body.Blocks {
+				if nested.Type != "default_tags" {
+					continue
+				}
+				if attr, exists := nested.Body.Attributes["tags"]; exists {
+					m.defaultTags[block.Labels[0]] = attr.Expr
+				}
+			}
+		}
+	}
+}
+
+// evalTagsInternal은 tags 표현식을 해석하여 tags에 합칩니다 (뒤에 나온 키가 우선).
+func (m *terraformModuleInternal) evalTagsInternal(expr hclsyntax.Expression, tags map[string]string, depth int) {
+	if depth > maxTagDepth {
+		return
+	}
+
+	switch e := expr.(type) {
+	case *hclsyntax.ObjectConsExpr:
+		for _, item := range e.Items {
+			key, diags := item.KeyExpr.Value(nil)
+			if diags.HasErrors() || key.Type() != cty.String || !key.IsKnown() || key.IsNull() {
+				// 따옴표 없는 키(Owner = ...)는 키워드로 처리
+				keyword := hcl.ExprAsKeyword(item.KeyExpr)
+				if keyword == "" {
+					continue
+				}
+				key = cty.StringVal(keyword)
+			}
+			tags[key.AsString()] = m.stringValueInternal(item.ValueExpr, depth)
+		}
+
+	case *hclsyntax.FunctionCallExpr:
+		if e.Name == "merge" {
+			for _, arg := range e.Args {
+				m.evalTagsInternal(arg, tags, depth+1)
+			}
+		}
+
+	case *hclsyntax.ScopeTraversalExpr:
+		if ref := m.referenceInternal(e); ref != nil {
+			m.evalTagsInternal(ref, tags, depth+1)
+		}
+
+	case *hclsyntax.ParenthesesExpr:
+		m.evalTagsInternal(e.Expression, tags, depth+1)
+	}
+}
+
+// stringValueInternal은 태그 값 표현식을 문자열로 만듭니다.
+// 리터럴과 local/var 참조는 해석하고, 그 외에는 "${표현식}" 형태의 원문을 반환합니다.
+func (m *terraformModuleInternal) stringValueInternal(expr hclsyntax.Expression, depth int) string {
+	if value, diags := expr.Value(nil); !diags.HasErrors() && value.IsKnown() && !value.IsNull() {
+		switch value.Type() {
+		case cty.String:
+			return value.AsString()
+		case cty.Number:
+			return value.AsBigFloat().String()
+		case cty.Bool:
+			if value.True() {
+				return "true"
+			}
+			return "false"
+		}
+	}
+
+	if traversal, ok := expr.(*hclsyntax.ScopeTraversalExpr); ok && depth < maxTagDepth {
+		if ref := m.referenceInternal(traversal); ref != nil {
+			return m.stringValueInternal(ref, depth+1)
+		}
+	}
+
+	rng := expr.Range()
+	if source, exists := m.sources[rng.Filename]; exists && rng.End.Byte <= len(source) {
+		text := strings.TrimSpace(string(source[rng.Start.Byte:rng.End.Byte]))
+		if strings.HasPrefix(text, `"`) {
+			return strings.Trim(text, `"`)
+		}
+		return "${" + text + "}"
+	}
+	return ""
+}
+
+// referenceInternal은 local.<이름>, var.<이름> 참조를 같은 모듈의 정의로 바꿉니다 (해석할 수 없으면 nil).
+func (m *terraformModuleInternal) referenceInternal(expr *hclsyntax.ScopeTraversalExpr) hclsyntax.Expression {
+	if len(expr.Traversal) != 2 {
+		return nil
+	}
+	attr, ok := expr.Traversal[1].(hcl.TraverseAttr)
+	if !ok {
+		return nil
+	}
+	switch expr.Traversal.RootName() {
+	case "local":
+		return m.locals[attr.Name]
+	case "var":
+		return m.variables[attr.Name]
+	}
+	return nil
+}
+
+// Lookup은 타겟 파일과 리소스 주소로 리소스를 찾습니다.
+// 인덱스(예: [0], ["a"])는 무시하며, 타겟 파일에 없으면 같은 모듈 디렉터리의 다른 파일에서 찾습니다.
+func (idx *TerraformIndex) Lookup(target, address string) *TerraformResource {
+	if i := strings.Index(address, "["); i >= 0 {
+		address = address[:i]
+	}
+	target = strings.TrimPrefix(path.Clean(strings.ReplaceAll(target, "\\", "/")), "./")
+
+	if resource, exists := idx.byFile[target][address]; exists {
+		return resource
+	}
+	return idx.byDir[path.Dir(target)][address]
+}
@@ -0,0 +1,167 @@
+package processor
+
+import (
+	"reflect"
+	"testing"
+)
+
+var terraformTestFiles = map[string][]byte{
+	"main.tf": []byte(`
+provider "aws" {
+  default_tags {
+    tags = {
+      Owner       = "platform"
+      Environment = "dev"
+    }
+  }
+}
+
+provider "aws" {
+  alias = "seoul"
+  default_tags {
+    tags = { Owner = "seoul-team" }
+  }
+}
+
+locals {
+  env    = "prod"
+  common = { "Team" = "core", Environment = local.env }
+  loop_a = local.loop_b
+  loop_b = local.loop_a
+}
+
+variable "cost_center" {
+  default = "cc-1"
+}
+
+variable "region" {}
+
+resource "aws_s3_bucket" "logs" {
+  tags = merge(local.common, {
+    CostCenter = var.cost_center
+    Name       = "${var.prefix}-logs"
+    Region     = var.region
+    Replicas   = 3
+    Public     = false
+  })
+}
+
+resource "aws_s3_bucket" "seoul" {
+  provider = aws.seoul
+  tags     = (local.common)
+}
+
+resource "aws_instance" "loop" {
+  tags = merge(local.loop_a, { Loop = local.loop_a })
+}
+
+resource "google_storage_bucket" "untagged" {
+}
+`),
+	"modules/app/main.tf": []byte(`
+resource "aws_lb" "public" {
+  tags = { Environment = local.env, Service = var.service }
+}
+`),
+	"modules/app/variables.tf": []byte(`
+variable "service" {
+  default = "app"
+}
+`),
+	"broken.tf": []byte(`resource "aws_s3_bucket" {`),
+}
+
+func TestParseTerraformFilesTags(t *testing.T) {
+	index := ParseTerraformFiles(terraformTestFiles)
+
+	tests := []struct {
+		name    string
+		target  string
+		address string
+		want    map[string]string
+	}{
+		{
+			name:    "merge with locals, vars and default_tags",
+			target:  "main.tf",
+			address: "aws_s3_bucket.logs",
+			want: map[string]string{
+				"Owner":       "platform",
+				"Environment": "prod", // 리소스 tags가 default_tags보다 우선
+				"Team":        "core",
+				"CostCenter":  "cc-1",
+				"Name":        "${var.prefix}-logs",
+				"Region":      "${var.region}", // default 없는 변수는 원문
+				"Replicas":    "3",
+				"Public":      "false",
+			},
+		},
+		{
+			name:    "aliased provider does not override default_tags",
+			target:  "main.tf",
+			address: "aws_s3_bucket.seoul",
+			want:    map[string]string{"Owner": "platform", "Environment": "prod", "Team": "core"},
+		},
+		{
+			name:    "reference cycle stops at max depth",
+			target:  "main.tf",
+			address: "aws_instance.loop",
+			want:    map[string]string{"Owner": "platform", "Environment": "dev", "Loop": "${local.loop_b}"},
+		},
+		{
+			name:    "other provider without default_tags",
+			target:  "main.tf",
+			address: "google_storage_bucket.untagged",
+		},
+		{
+			name:    "locals are scoped to the module directory",
+			target:  "modules/app/main.tf",
+			address: "aws_lb.public[0]",
+			want:    map[string]string{"Environment": "${local.env}", "Service": "app"},
+		},
+		{
+			name:    "same directory fallback",
+			target:  "./modules/app/other.tf",
+			address: `aws_lb.public["a"]`,
+			want:    map[string]string{"Environment": "${local.env}", "Service": "app"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource := index.Lookup(tt.target, tt.address)
+			if resource == nil {
+				t.Fatalf("Lookup(%q, %q) = nil", tt.target, tt.address)
+			}
+			if !reflect.DeepEqual(resource.Tags, tt.want) {
+				t.Errorf("Tags = %v, want %v", resource.Tags, tt.want)
+			}
+		})
+	}
+}
+
+func TestTerraformIndexLookup(t *testing.T) {
+	index := ParseTerraformFiles(terraformTestFiles)
+
+	resource := index.Lookup(`modules\app\variables.tf`, "aws_lb.public")
+	if resource == nil {
+		t.Fatal("Lookup with windows path = nil")
+	}
+	want := TerraformResource{Address: "aws_lb.public", File: "modules/app/main.tf", ModuleDir: "modules/app", StartLine: 2, EndLine: 4, Tags: resource.Tags}
+	if !reflect.DeepEqual(*resource, want) {
+		t.Errorf("resource = %+v, want %+v", *resource, want)
+	}
+
+	missing := []struct{ target, address string }{
+		{target: "other/main.tf", address: "aws_lb.public"},
+		{target: "modules/app/main.tf", address: "aws_s3_bucket.logs"},
+		{target: "main.tf", address: "aws_lb.public"},
+	}
+	for _, m := range missing {
+		if got := index.Lookup(m.target, m.address); got != nil {
+			t.Errorf("Lookup(%q, %q) = %+v, want nil", m.target, m.address, got)
+		}
+	}
+
+	if len(index.Skipped) != 1 {
+		t.Errorf("Skipped = %v, want broken.tf only", index.Skipped)
+	}
+}
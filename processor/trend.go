@@ -0,0 +1,131 @@
+package processor
+
+import (
+	"sort"
+	"time"
+)
+
+// TrendData는 여러 스캔 결과의 시계열 추이를 담습니다.
+type TrendData struct {
+	Points []TrendPoint
+}
+
+// TrendPoint는 스캔 1회분의 심각도별/카테고리별 집계입니다.
+type TrendPoint struct {
+	CreatedAt    string
+	ArtifactName string
+	Severity     SeveritySummary
+	Categories   map[string]int // 정책 카테고리별 검출 수
+	Total        int
+}
+
+// findingSeen은 finding의 최초/최종 발견 시점을 기록합니다.
+type findingSeen struct {
+	FirstSeen string
+	LastSeen  string
+}
+
+// SortScansByCreatedAt은 스캔 결과를 CreatedAt 기준 오름차순으로 정렬합니다.
+// 파싱할 수 없는 CreatedAt은 문자열 비교로 정렬합니다.
+func SortScansByCreatedAt(scans []*TrivyResult) {
+	sort.SliceStable(scans, func(i, j int) bool {
+		ti, errI := ParseCreatedAt(scans[i].CreatedAt)
+		tj, errJ := ParseCreatedAt(scans[j].CreatedAt)
+		if errI != nil || errJ != nil {
+			return scans[i].CreatedAt < scans[j].CreatedAt
+		}
+		return ti.Before(tj)
+	})
+}
+
+// ParseCreatedAt은 Trivy의 CreatedAt(RFC3339) 문자열을 time.Time으로 변환합니다.
+func ParseCreatedAt(createdAt string) (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, createdAt)
+}
+
+// LatestScan은 CreatedAt이 가장 늦은 스캔을 반환합니다 (목록이 비어 있으면 nil).
+func LatestScan(scans []*TrivyResult) *TrivyResult {
+	if len(scans) == 0 {
+		return nil
+	}
+	sorted := make([]*TrivyResult, len(scans))
+	copy(sorted, scans)
+	SortScansByCreatedAt(sorted)
+	return sorted[len(sorted)-1]
+}
+
+// formatScanDate는 CreatedAt을 "2006-01-02" 형식의 날짜로 변환합니다.
+func formatScanDate(createdAt string) string {
+	if t, err := ParseCreatedAt(createdAt); err == nil {
+		return t.Format("2006-01-02")
+	}
+	return createdAt
+}
+
+// PrepareTrendExcelData는 여러 스캔 결과로부터 Excel 데이터를 생성합니다.
+// 시트에는 가장 최근 스캔의 finding을 출력하고, 각 행에 최초/최종 발견일을 채웁니다.
+// 스캔별 심각도/카테고리 집계는 Trend에 담습니다 (classifier가 nil이면 DefaultClassifier).
+func PrepareTrendExcelData(scans []*TrivyResult, classifier *Classifier) *ExcelData {
+	if classifier == nil {
+		classifier = DefaultClassifier()
+	}
+
+	sorted := make([]*TrivyResult, len(scans))
+	copy(sorted, scans)
+	SortScansByCreatedAt(sorted)
+
+	trend := &TrendData{Points: make([]TrendPoint, 0, len(sorted))}
+	seen := make(map[string]*findingSeen)
+
+	for _, scan := range sorted {
+		date := formatScanDate(scan.CreatedAt)
+		point := TrendPoint{
+			CreatedAt:    scan.CreatedAt,
+			ArtifactName: scan.ArtifactName,
+			Categories:   make(map[string]int),
+		}
+
+		for _, result := range scan.Results {
+			for _, misconfig := range result.Misconfigurations {
+				point.Severity.addSeverity(misconfig.Severity)
+				point.Categories[classifier.Classify(misconfig.Namespace, misconfig.ID)]++
+				point.Total++
+
+				key := Fingerprint(result.Target, misconfig.ID, misconfig.CauseMetadata.Resource)
+				if s, exists := seen[key]; exists {
+					s.LastSeen = date
+				} else {
+					seen[key] = &findingSeen{FirstSeen: date, LastSeen: date}
+				}
+			}
+		}
+
+		trend.Points = append(trend.Points, point)
+	}
+
+	if len(sorted) == 0 {
+		excelData := newExcelDataInternal(classifier)
+		excelData.Trend = trend
+		return excelData
+	}
+
+	// 최신 스캔 기준으로 행 생성 후 발견일 채우기
+	latest := sorted[len(sorted)-1]
+	excelData := PrepareExcelData(latest, classifier)
+	excelData.Trend = trend
+	for _, category := range excelData.Categories {
+		fillSeenDatesInternal(category.Rows, seen)
+	}
+
+	return excelData
+}
+
+// fillSeenDatesInternal은 Excel 행에 최초/최종 발견일을 채웁니다.
+func fillSeenDatesInternal(rows []ExcelRow, seen map[string]*findingSeen) {
+	for i := range rows {
+		if s, exists := seen[rows[i].Fingerprint]; exists {
+			rows[i].FirstSeen = s.FirstSeen
+			rows[i].LastSeen = s.LastSeen
+		}
+	}
+}
@@ -75,7 +75,8 @@ type Location struct {
 	EndLine   int `json:"EndLine"`
 }
 
-// 그룹화된 결과 구조체 (Type, AVDID, Query, References 제외)
+// 그룹화된 결과 구조체
+// Type, AVDID, Query, References는 기본 JSON 출력에서 제외하며, -fields 옵션으로 포함할 수 있습니다.
 type GroupedMisconfiguration struct {
 	ID          string      `json:"ID"`
 	Title       string      `json:"Title"`
@@ -86,6 +87,20 @@ type GroupedMisconfiguration struct {
 	PrimaryURL  string      `json:"PrimaryURL"`
 	Status      string      `json:"Status"`
 	Violations  []Violation `json:"Violations"`
+
+	// Compliance는 -compliance 옵션 사용 시 채워지는 프레임워크별 통제 항목 참조입니다.
+	Compliance map[string][]string `json:"Compliance,omitempty"`
+
+	// -policy-dir 옵션 사용 시 Rego METADATA에서 채워지는 필드입니다.
+	Owner               string   `json:"Owner,omitempty"`
+	RemediationExamples string   `json:"RemediationExamples,omitempty"`
+	RelatedResources    []string `json:"RelatedResources,omitempty"`
+	RiskRationale       string   `json:"RiskRationale,omitempty"`
+
+	Type       string   `json:"-"`
+	AVDID      string   `json:"-"`
+	Query      string   `json:"-"`
+	References []string `json:"-"`
 }
 
 type Violation struct {
@@ -95,6 +110,37 @@ type Violation struct {
 	StartLine int    `json:"StartLine"`
 	EndLine   int    `json:"EndLine"`
 	Message   string `json:"Message"`
+
+	// 모듈 안에서 검출된 경우 호출한 모듈 인스턴스(예: module.app.module.logs)와 루트 파일
+	Module   string `json:"Module,omitempty"`
+	RootFile string `json:"RootFile,omitempty"`
+
+	// Occurrences는 원인 리소스를 감싼 블록부터 루트 모듈 호출까지의 체인입니다 (모듈 안의 finding만).
+	Occurrences []Occurrence `json:"Occurrences,omitempty"`
+
+	// CodeOwners는 -codeowners 옵션 사용 시 타겟 경로의 담당자 목록입니다.
+	CodeOwners []string `json:"CodeOwners,omitempty"`
+
+	// Terraform은 -terraform-root 옵션 사용 시 로컬 .tf에서 찾은 리소스 정보(파일, 모듈 디렉터리, 태그)입니다.
+	Terraform *TerraformResource `json:"Terraform,omitempty"`
+
+	// RiskWeight는 -tag-weights 옵션 사용 시 리소스 태그에 따른 위험 가중치입니다.
+	RiskWeight float64 `json:"RiskWeight,omitempty"`
+
+	// Risk는 -risk 옵션 사용 시 위험 점수와 계산에 사용한 배수입니다.
+	Risk *RiskScore `json:"Risk,omitempty"`
+
+	// SLA는 -sla 옵션 사용 시 조치 기한과 초과 여부입니다.
+	SLA *SLAStatus `json:"SLA,omitempty"`
+
+	// Blame은 -blame 옵션 사용 시 라인 범위를 마지막으로 수정한 커밋 정보입니다.
+	Blame *BlameInfo `json:"Blame,omitempty"`
+
+	// Source는 -source-root 옵션 사용 시 로컬 체크아웃에서 읽은 원인 라인 주변 코드입니다.
+	Source *SourceSnippet `json:"Source,omitempty"`
+
+	// Code는 원본 CauseMetadata.Code로, 템플릿 렌더링 등 메모리 내 처리에만 사용하며 JSON에는 포함하지 않습니다.
+	Code *CodeBlock `json:"-"`
 }
 
 type GroupedResult struct {
@@ -110,6 +156,7 @@ type GroupedTrivyResult struct {
 	CreatedAt       string           `json:"CreatedAt"`
 	ArtifactName    string           `json:"ArtifactName"`
 	ArtifactType    string           `json:"ArtifactType"`
+	Owner           string           `json:"Owner,omitempty"` // -split-by owner 사용 시 담당자
 	SeveritySummary *SeveritySummary `json:"SeveritySummary,omitempty"`
 	Results         []GroupedResult  `json:"Results"`
 }
@@ -0,0 +1,87 @@
+package render
+
+import (
+	"sort"
+	"strings"
+	"text/template"
+	"trivy-parser/processor"
+)
+
+// FuncMap은 템플릿에서 사용할 수 있는 헬퍼 함수 목록입니다.
+//
+//	severityRank "HIGH"             -> 1 (CRITICAL=0 ... 알 수 없음=4)
+//	sortBySeverity .Policies        -> 심각도 높은 순으로 정렬된 복사본
+//	snippet .                       -> Violation의 코드 전체 (원인 라인은 ">" 표시)
+//	causeSnippet .                  -> Violation의 원인 라인만
+//	truncate 200 .Description       -> 200자 초과 시 잘라내고 "..." 추가
+//	indent 4 .Text                  -> 각 라인 앞에 공백 4칸 추가
+//	slackEscape .Title              -> Slack mrkdwn 제어 문자(&, <, >) 이스케이프
+//	trim, upper, lower, join        -> strings 패키지 함수
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"severityRank":   processor.SeverityRank,
+		"sortBySeverity": sortBySeverity,
+		"snippet":        snippet,
+		"causeSnippet":   causeSnippet,
+		"truncate":       truncate,
+		"indent":         indent,
+		"slackEscape":    slackEscape,
+		"trim":           strings.TrimSpace,
+		"upper":          strings.ToUpper,
+		"lower":          strings.ToLower,
+		"join":           strings.Join,
+	}
+}
+
+// sortBySeverity는 정책 목록을 심각도 높은 순으로 정렬한 복사본을 반환합니다.
+func sortBySeverity(policies []processor.GroupedMisconfiguration) []processor.GroupedMisconfiguration {
+	sorted := make([]processor.GroupedMisconfiguration, len(policies))
+	copy(sorted, policies)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return processor.SeverityRank(sorted[i].Severity) < processor.SeverityRank(sorted[j].Severity)
+	})
+	return sorted
+}
+
+// snippet은 Violation의 코드 블록 전체를 텍스트로 반환합니다.
+func snippet(violation processor.Violation) string {
+	return processor.FormatCodeSnippet(violation.Code)
+}
+
+// causeSnippet은 Violation의 코드 블록 중 원인 라인만 텍스트로 반환합니다.
+func causeSnippet(violation processor.Violation) string {
+	if violation.Code == nil {
+		return ""
+	}
+
+	causes := &processor.CodeBlock{}
+	for _, line := range violation.Code.Lines {
+		if line.IsCause {
+			causes.Lines = append(causes.Lines, line)
+		}
+	}
+	return processor.FormatCodeSnippet(causes)
+}
+
+// truncate는 문자열이 max 글자를 넘으면 잘라내고 "..."을 붙입니다.
+func truncate(max int, s string) string {
+	runes := []rune(s)
+	if max <= 0 || len(runes) <= max {
+		return s
+	}
+	return strings.TrimRight(string(runes[:max]), " \n") + "..."
+}
+
+// indent는 각 라인 앞에 공백 n칸을 추가합니다.
+func indent(n int, s string) string {
+	pad := strings.Repeat(" ", n)
+	return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+}
+
+// slackEscapeReplacer는 Slack mrkdwn에서 제어 문자로 해석되는 문자를 이스케이프합니다.
+var slackEscapeReplacer = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// slackEscape는 Slack 메시지 텍스트의 &, <, >를 이스케이프하여 링크나 멘션으로 해석되지 않도록 합니다.
+func slackEscape(s string) string {
+	return slackEscapeReplacer.Replace(s)
+}
@@ -0,0 +1,144 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"trivy-parser/processor"
+)
+
+// NotifyFormat은 채팅 웹후크 페이로드 형식입니다.
+type NotifyFormat string
+
+const (
+	// NotifyAuto는 웹후크 URL 호스트로 형식을 결정합니다 (Teams/Power Automate 호스트가 아니면 Slack).
+	NotifyAuto NotifyFormat = "auto"
+	// NotifySlack은 Slack 호환 incoming webhook 형식입니다 ({"text": ...}, Mattermost 등 호환).
+	NotifySlack NotifyFormat = "slack"
+	// NotifyTeams는 Microsoft Teams 호환 webhook 형식입니다 (Adaptive Card 첨부 메시지).
+	NotifyTeams NotifyFormat = "teams"
+)
+
+// teamsHostSuffixes는 Teams 형식으로 판단하는 웹후크 호스트 접미사입니다.
+var teamsHostSuffixes = []string{".office.com", ".logic.azure.com", ".powerplatform.com", ".powerautomate.com"}
+
+// ParseNotifyFormat은 문자열을 NotifyFormat으로 변환합니다.
+func ParseNotifyFormat(s string) (NotifyFormat, error) {
+	switch format := NotifyFormat(strings.ToLower(s)); format {
+	case NotifyAuto, NotifySlack, NotifyTeams:
+		return format, nil
+	}
+	return "", fmt.Errorf("unknown notify format: %q (supported: auto, slack, teams)", s)
+}
+
+// DetectNotifyFormat은 NotifyAuto일 때 웹후크 URL 호스트로 형식을 결정합니다.
+func DetectNotifyFormat(format NotifyFormat, webhook string) NotifyFormat {
+	if format != NotifyAuto {
+		return format
+	}
+	if u, err := url.Parse(webhook); err == nil {
+		host := "." + strings.ToLower(u.Hostname())
+		for _, suffix := range teamsHostSuffixes {
+			if strings.HasSuffix(host, suffix) {
+				return NotifyTeams
+			}
+		}
+	}
+	return NotifySlack
+}
+
+// Notifier는 알림 요약을 템플릿으로 렌더링하여 웹후크 페이로드를 만듭니다.
+type Notifier struct {
+	tmpl   *template.Template
+	format NotifyFormat
+}
+
+// LoadNotifier는 형식별 내장 템플릿(spec이 빈 값) 또는 템플릿 파일로 Notifier를 생성합니다.
+// 템플릿에는 processor.NotifySummary가 전달되며 FuncMap 함수를 사용할 수 있습니다.
+func LoadNotifier(spec string, format NotifyFormat) (*Notifier, error) {
+	switch format {
+	case NotifySlack, NotifyTeams:
+	default:
+		return nil, fmt.Errorf("unknown notify format: %q (supported: slack, teams)", format)
+	}
+
+	name := "notify-" + string(format) + ".tmpl"
+	var content []byte
+	var err error
+	if spec == "" {
+		content, err = builtinTemplates.ReadFile("templates/" + name)
+	} else {
+		name = filepath.Base(spec)
+		content, err = os.ReadFile(spec)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New(name).Funcs(FuncMap()).Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("template parse failed: %w", err)
+	}
+	return &Notifier{tmpl: tmpl, format: format}, nil
+}
+
+// Format은 페이로드 형식을 반환합니다.
+func (n *Notifier) Format() NotifyFormat {
+	return n.format
+}
+
+// Payload는 요약을 렌더링하여 웹후크 JSON 페이로드를 만듭니다.
+func (n *Notifier) Payload(summary *processor.NotifySummary, pretty bool) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := n.tmpl.Execute(&buf, summary); err != nil {
+		return nil, fmt.Errorf("render notification: %w", err)
+	}
+	text := strings.TrimSpace(buf.String())
+
+	var payload interface{}
+	if n.format == NotifyTeams {
+		payload = teamsPayloadInternal(text, summary.Link)
+	} else {
+		payload = map[string]string{"text": text}
+	}
+
+	// Slack 링크 문법(<url|text>)이 그대로 보이도록 HTML 이스케이프 없이 인코딩
+	var out bytes.Buffer
+	encoder := json.NewEncoder(&out)
+	encoder.SetEscapeHTML(false)
+	if pretty {
+		encoder.SetIndent("", "  ")
+	}
+	if err := encoder.Encode(payload); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(out.Bytes(), "\n"), nil
+}
+
+// teamsPayloadInternal은 Adaptive Card 1장을 첨부한 Teams 메시지를 만듭니다 (링크는 버튼으로 표시).
+func teamsPayloadInternal(text, link string) map[string]interface{} {
+	card := map[string]interface{}{
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"type":    "AdaptiveCard",
+		"version": "1.4",
+		"body": []map[string]interface{}{
+			{"type": "TextBlock", "text": text, "wrap": true},
+		},
+	}
+	if link != "" {
+		card["actions"] = []map[string]string{
+			{"type": "Action.OpenUrl", "title": "View artifacts", "url": link},
+		}
+	}
+	return map[string]interface{}{
+		"type": "message",
+		"attachments": []map[string]interface{}{
+			{"contentType": "application/vnd.microsoft.card.adaptive", "content": card},
+		},
+	}
+}
@@ -0,0 +1,129 @@
+package render
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"trivy-parser/processor"
+)
+
+func TestDetectNotifyFormat(t *testing.T) {
+	tests := []struct {
+		format  NotifyFormat
+		webhook string
+		want    NotifyFormat
+	}{
+		{format: NotifyAuto, webhook: "https://hooks.slack.com/services/T/B/X", want: NotifySlack},
+		{format: NotifyAuto, webhook: "https://contoso.webhook.office.com/webhookb2/x", want: NotifyTeams},
+		{format: NotifyAuto, webhook: "https://OUTLOOK.OFFICE.COM/webhook/x", want: NotifyTeams},
+		{format: NotifyAuto, webhook: "https://office.com/webhook", want: NotifyTeams},
+		{format: NotifyAuto, webhook: "https://prod-01.westus.logic.azure.com:443/workflows/x", want: NotifyTeams},
+		{format: NotifyAuto, webhook: "https://x.environment.api.powerplatform.com/x", want: NotifyTeams},
+		{format: NotifyAuto, webhook: "https://evil-office.com/webhook", want: NotifySlack},
+		{format: NotifyAuto, webhook: "https://outlook.office.com.evil.io/webhook", want: NotifySlack},
+		{format: NotifyAuto, webhook: "https://mattermost.example.com/hooks/x?next=.office.com", want: NotifySlack},
+		{format: NotifyAuto, webhook: "://bad url", want: NotifySlack},
+		{format: NotifySlack, webhook: "https://contoso.webhook.office.com/x", want: NotifySlack},
+		{format: NotifyTeams, webhook: "https://hooks.slack.com/x", want: NotifyTeams},
+	}
+	for _, tt := range tests {
+		if got := DetectNotifyFormat(tt.format, tt.webhook); got != tt.want {
+			t.Errorf("DetectNotifyFormat(%q, %q) = %q, want %q", tt.format, tt.webhook, got, tt.want)
+		}
+	}
+}
+
+func TestParseNotifyFormat(t *testing.T) {
+	for _, s := range []string{"auto", "Slack", "TEAMS"} {
+		if _, err := ParseNotifyFormat(s); err != nil {
+			t.Errorf("ParseNotifyFormat(%q): %v", s, err)
+		}
+	}
+	if _, err := ParseNotifyFormat("discord"); err == nil {
+		t.Error("ParseNotifyFormat(discord): expected error")
+	}
+}
+
+func notifyTestSummaryInternal() *processor.NotifySummary {
+	return &processor.NotifySummary{
+		ArtifactName: "app<prod>&co",
+		Total:        1,
+		Severity:     processor.SeveritySummary{High: 1},
+		TopNew: []processor.NotifyFinding{{
+			Severity:  "HIGH",
+			PolicyID:  "AVD-AWS-0001",
+			Title:     "Use <b> & <!channel>",
+			Target:    "main.tf",
+			Resource:  "aws_s3_bucket.logs",
+			StartLine: 3,
+		}},
+		Link: "https://ci.example.com/run?id=1&tab=artifacts",
+	}
+}
+
+func TestNotifierSlackPayload(t *testing.T) {
+	notifier, err := LoadNotifier("", NotifySlack)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := notifier.Payload(notifyTestSummaryInternal(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// JSON은 HTML 이스케이프(\u003c) 없이 인코딩
+	if strings.Contains(string(data), `\u003c`) || strings.Contains(string(data), `\u0026`) {
+		t.Errorf("payload is HTML-escaped: %s", data)
+	}
+
+	var payload map[string]string
+	if err := json.Unmarshal(data, &payload); err != nil {
+		t.Fatal(err)
+	}
+	text := payload["text"]
+	for _, want := range []string{
+		"*Trivy scan: app&lt;prod&gt;&amp;co*",
+		"AVD-AWS-0001 Use &lt;b&gt; &amp; &lt;!channel&gt; — `main.tf` aws_s3_bucket.logs:3",
+		"<https://ci.example.com/run?id=1&tab=artifacts|View artifacts>",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("text does not contain %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "<!channel>") || strings.Contains(text, "<b>") {
+		t.Errorf("text contains unescaped markup:\n%s", text)
+	}
+}
+
+func TestNotifierTeamsPayload(t *testing.T) {
+	notifier, err := LoadNotifier("", NotifyTeams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := notifier.Payload(notifyTestSummaryInternal(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var payload struct {
+		Type        string `json:"type"`
+		Attachments []struct {
+			Content struct {
+				Body    []struct{ Text string } `json:"body"`
+				Actions []struct{ URL string }  `json:"actions"`
+			} `json:"content"`
+		} `json:"attachments"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload.Type != "message" || len(payload.Attachments) != 1 {
+		t.Fatalf("payload = %s", data)
+	}
+	card := payload.Attachments[0].Content
+	if len(card.Body) != 1 || !strings.Contains(card.Body[0].Text, "**Trivy scan: app<prod>&co**") {
+		t.Errorf("body = %+v", card.Body)
+	}
+	if len(card.Actions) != 1 || card.Actions[0].URL != "https://ci.example.com/run?id=1&tab=artifacts" {
+		t.Errorf("actions = %+v", card.Actions)
+	}
+}
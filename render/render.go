@@ -0,0 +1,219 @@
+package render
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"trivy-parser/processor"
+)
+
+//go:embed templates/*.tmpl
+var builtinTemplates embed.FS
+
+// Scope는 템플릿 1회 실행의 단위입니다.
+type Scope string
+
+const (
+	// ScopeTarget은 Preprocess 결과 파일(타겟) 1개당 1회 렌더링합니다.
+	ScopeTarget Scope = "target"
+	// ScopePolicy는 카테고리별 정책 1개당 1회 렌더링하며, 해당 정책이 검출된 모든 타겟을 포함합니다.
+	ScopePolicy Scope = "policy"
+)
+
+// builtins는 내장 템플릿 이름과 렌더링 단위입니다.
+var builtins = map[string]Scope{
+	"remediation": ScopeTarget,
+	"policy":      ScopePolicy,
+}
+
+// Renderer는 그룹화된 결과를 텍스트로 렌더링합니다.
+type Renderer struct {
+	tmpl  *template.Template
+	scope Scope
+}
+
+// Data는 템플릿에 전달되는 값입니다.
+type Data struct {
+	Key      string                              // 출력 키 (예: "builtin-main.tf", "builtin-AVD-AWS-0086")
+	Category string                              // 정책 카테고리 (기본: builtin/custom)
+	Target   string                              // ScopeTarget: 타겟 파일 경로
+	Summary  processor.SeveritySummary           // 심각도 요약
+	Result   *processor.GroupedTrivyResult       // 렌더링 대상 전체 결과
+	Policies []processor.GroupedMisconfiguration // 포함된 모든 정책 (입력 순서)
+
+	// ScopePolicy 전용
+	Policy     *processor.GroupedMisconfiguration // 정책 메타데이터
+	Targets    []TargetViolations                 // 타겟별 violation
+	Violations []processor.Violation              // 모든 타겟의 violation
+}
+
+// TargetViolations는 정책 단위 렌더링에서 타겟 1개의 violation 목록입니다.
+type TargetViolations struct {
+	Target     string
+	Violations []processor.Violation
+}
+
+// Rendered는 렌더링 결과 1건입니다.
+type Rendered struct {
+	Key    string
+	Text   string
+	Result *processor.GroupedTrivyResult // 인덱스 작성 등에 사용하는 원본 데이터
+}
+
+// BuiltinNames는 내장 템플릿 이름 목록을 반환합니다.
+func BuiltinNames() []string {
+	names := make([]string, 0, len(builtins))
+	for name := range builtins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Load는 내장 템플릿 이름 또는 템플릿 파일 경로로 Renderer를 생성합니다.
+// 내장 템플릿은 자체 렌더링 단위를 사용하며, 파일 템플릿은 scope를 사용합니다.
+func Load(spec string, scope Scope) (*Renderer, error) {
+	if builtinScope, ok := builtins[spec]; ok {
+		content, err := builtinTemplates.ReadFile("templates/" + spec + ".tmpl")
+		if err != nil {
+			return nil, err
+		}
+		return New(spec, string(content), builtinScope)
+	}
+
+	content, err := os.ReadFile(spec)
+	if err != nil {
+		return nil, fmt.Errorf("template %q is neither a builtin (%s) nor a readable file: %w",
+			spec, strings.Join(BuiltinNames(), ", "), err)
+	}
+	return New(filepath.Base(spec), string(content), scope)
+}
+
+// New는 템플릿 문자열로 Renderer를 생성합니다.
+func New(name, content string, scope Scope) (*Renderer, error) {
+	switch scope {
+	case ScopeTarget, ScopePolicy:
+	default:
+		return nil, fmt.Errorf("unknown render scope: %q (supported: target, policy)", scope)
+	}
+
+	tmpl, err := template.New(name).Funcs(FuncMap()).Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("template parse failed: %w", err)
+	}
+	return &Renderer{tmpl: tmpl, scope: scope}, nil
+}
+
+// Render는 Preprocess 결과를 렌더링 단위별로 렌더링합니다. 결과는 키 순으로 정렬됩니다.
+func (r *Renderer) Render(targetMap map[string]*processor.GroupedTrivyResult) ([]Rendered, error) {
+	var inputs []Data
+	if r.scope == ScopePolicy {
+		inputs = policyDataInternal(targetMap)
+	} else {
+		inputs = targetDataInternal(targetMap)
+	}
+
+	rendered := make([]Rendered, 0, len(inputs))
+	for _, data := range inputs {
+		var buf bytes.Buffer
+		if err := r.tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("render %s: %w", data.Key, err)
+		}
+		rendered = append(rendered, Rendered{Key: data.Key, Text: buf.String(), Result: data.Result})
+	}
+	return rendered, nil
+}
+
+// targetDataInternal은 타겟별 템플릿 데이터를 생성합니다.
+func targetDataInternal(targetMap map[string]*processor.GroupedTrivyResult) []Data {
+	var inputs []Data
+	for _, key := range processor.SortedTargetKeys(targetMap) {
+		result := targetMap[key]
+		category, target := processor.SplitTargetKey(key, result)
+
+		data := Data{
+			Key:      key,
+			Category: category,
+			Target:   target,
+			Result:   result,
+		}
+		if result.SeveritySummary != nil {
+			data.Summary = *result.SeveritySummary
+		}
+		for _, res := range result.Results {
+			data.Policies = append(data.Policies, res.Misconfigurations...)
+		}
+		inputs = append(inputs, data)
+	}
+	return inputs
+}
+
+// policyDataInternal은 카테고리별 정책 단위 템플릿 데이터를 생성합니다.
+func policyDataInternal(targetMap map[string]*processor.GroupedTrivyResult) []Data {
+	byKey := make(map[string]*Data)
+	var keys []string
+
+	for _, key := range processor.SortedTargetKeys(targetMap) {
+		result := targetMap[key]
+		category, _ := processor.SplitTargetKey(key, result)
+
+		for _, res := range result.Results {
+			for _, misconfig := range res.Misconfigurations {
+				policyKey := category + "-" + misconfig.ID
+				data, exists := byKey[policyKey]
+				if !exists {
+					policy := misconfig
+					policy.Violations = nil
+					data = &Data{
+						Key:      policyKey,
+						Category: category,
+						Policy:   &policy,
+						Result: &processor.GroupedTrivyResult{
+							SchemaVersion:   result.SchemaVersion,
+							CreatedAt:       result.CreatedAt,
+							ArtifactName:    result.ArtifactName,
+							ArtifactType:    result.ArtifactType,
+							SeveritySummary: &processor.SeveritySummary{},
+						},
+					}
+					byKey[policyKey] = data
+					keys = append(keys, policyKey)
+				}
+
+				// 타겟별 결과에 해당 정책만 포함
+				targetResult := res
+				targetResult.Misconfigurations = []processor.GroupedMisconfiguration{misconfig}
+				targetResult.MisconfSummary.Failures = 1
+				data.Result.Results = append(data.Result.Results, targetResult)
+
+				data.Policies = append(data.Policies, misconfig)
+				data.Targets = append(data.Targets, TargetViolations{Target: res.Target, Violations: misconfig.Violations})
+				data.Violations = append(data.Violations, misconfig.Violations...)
+			}
+		}
+	}
+
+	sort.Strings(keys)
+	inputs := make([]Data, 0, len(keys))
+	for _, key := range keys {
+		data := byKey[key]
+		switch strings.ToUpper(data.Policy.Severity) {
+		case "CRITICAL":
+			data.Summary.Critical = 1
+		case "HIGH":
+			data.Summary.High = 1
+		case "MEDIUM":
+			data.Summary.Medium = 1
+		case "LOW":
+			data.Summary.Low = 1
+		}
+		*data.Result.SeveritySummary = data.Summary
+		inputs = append(inputs, *data)
+	}
+	return inputs
+}
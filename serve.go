@@ -0,0 +1,273 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	stdio "io"
+	"mime"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+	"trivy-parser/cli"
+	"trivy-parser/i18n"
+	"trivy-parser/io"
+	"trivy-parser/processor"
+)
+
+// 변환 결과 Content-Type
+const (
+	contentTypeZip  = "application/zip"
+	contentTypeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// errMultiInput은 여러 스캔을 지원하지 않는 변환에 여러 파일을 업로드했을 때의 에러입니다.
+var errMultiInput = errors.New("multiple scans are supported only for Excel")
+
+// converter는 요청 설정과 업로드된 스캔으로 변환 결과를 w에 기록합니다.
+type converter func(config *cli.Config, scans []*processor.TrivyResult, w stdio.Writer, warn func(string)) error
+
+// server는 HTTP 변환 서버입니다.
+type server struct {
+	config *cli.ServeConfig
+	slots  chan struct{} // 동시 변환 제한 (-max-concurrent)
+}
+
+// runServe는 HTTP 변환 서버를 실행합니다 (parser serve). SIGINT/SIGTERM을 받으면 진행 중인 요청을 마치고 종료합니다.
+func runServe(config *cli.ServeConfig) {
+	s := &server{config: config, slots: make(chan struct{}, config.MaxConcurrent)}
+	httpServer := &http.Server{
+		Addr:              config.Addr,
+		Handler:           s.routes(),
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       config.Timeout,
+		WriteTimeout:      2 * config.Timeout,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+		defer cancel()
+		httpServer.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Println(i18n.T("cli.serve_listening", config.Addr))
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fmt.Fprintln(os.Stderr, i18n.T("cli.error", err))
+		os.Exit(1)
+	}
+}
+
+// routes는 서버 엔드포인트를 등록합니다.
+//
+//	GET  /healthz         상태 확인
+//	POST /v1/preprocess   preprocess 결과(타겟별 파일 + 리포트)를 zip으로 반환
+//	POST /v1/excel        .xlsx 반환 (여러 스캔 업로드 시 Trend 시트 포함)
+func (s *server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeJSONResponse(w, http.StatusOK, map[string]interface{}{
+			"status":    "ok",
+			"active":    len(s.slots),
+			"capacity":  cap(s.slots),
+			"maxUpload": s.config.MaxUpload,
+		})
+	})
+	mux.Handle("POST /v1/preprocess", s.convertHandler("preprocess.zip", contentTypeZip, convertPreprocess))
+	mux.Handle("POST /v1/excel", s.convertHandler("result.xlsx", contentTypeXLSX, convertExcel))
+	return s.logRequests(mux)
+}
+
+// convertHandler는 업로드 읽기, 요청 옵션 파싱, 동시 실행/시간 제한을 처리하고 변환 결과를 첨부 파일로 반환합니다.
+// 결과는 메모리에 만든 뒤 전송하므로 변환 중 오류가 나도 잘린 파일 대신 오류 응답을 보냅니다.
+func (s *server) convertHandler(filename, contentType string, convert converter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case s.slots <- struct{}{}:
+		default:
+			w.Header().Set("Retry-After", "1")
+			writeError(w, http.StatusTooManyRequests, errors.New(i18n.T("err.serve_busy")))
+			return
+		}
+		handedOff := false // 변환 고루틴에 슬롯을 넘긴 뒤에는 고루틴이 반환
+		defer func() {
+			if !handedOff {
+				<-s.slots
+			}
+		}()
+
+		config, err := s.config.RequestConfig(r.URL.Query())
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		scans, err := readUploads(w, r, s.config.MaxUpload)
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				writeError(w, http.StatusRequestEntityTooLarge, err)
+				return
+			}
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		// 변환은 별도 고루틴에서 실행하고, 시간 초과 시 응답만 먼저 반환 (슬롯은 변환이 끝날 때 반환)
+		type outcome struct {
+			body     bytes.Buffer
+			warnings []string
+			err      error
+		}
+		done := make(chan *outcome, 1)
+		handedOff = true
+		go func() {
+			defer func() { <-s.slots }()
+			result := &outcome{}
+			result.err = convert(config, scans, &result.body, func(message string) {
+				result.warnings = append(result.warnings, message)
+			})
+			done <- result
+		}()
+
+		timer := time.NewTimer(s.config.Timeout)
+		defer timer.Stop()
+		select {
+		case result := <-done:
+			for _, message := range result.warnings {
+				printWarning(message)
+			}
+			switch {
+			case errors.Is(result.err, errNoTargets):
+				writeError(w, http.StatusUnprocessableEntity, errors.New(i18n.T("cli.no_tf_files")))
+			case errors.Is(result.err, errMultiInput):
+				writeError(w, http.StatusBadRequest, errors.New(i18n.T("err.serve_multi_input")))
+			case result.err != nil:
+				writeError(w, http.StatusInternalServerError, result.err)
+			default:
+				w.Header().Set("Content-Type", contentType)
+				w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+				w.Header().Set("Content-Length", fmt.Sprint(result.body.Len()))
+				w.WriteHeader(http.StatusOK)
+				result.body.WriteTo(w)
+			}
+		case <-timer.C:
+			writeError(w, http.StatusServiceUnavailable, errors.New(i18n.T("err.serve_timeout", s.config.Timeout)))
+		case <-r.Context().Done():
+		}
+	})
+}
+
+// convertPreprocess는 preprocess 결과를 zip으로 기록합니다 (출력 파일 구조는 -output 디렉토리와 동일).
+func convertPreprocess(config *cli.Config, scans []*processor.TrivyResult, w stdio.Writer, warn func(string)) error {
+	if len(scans) > 1 {
+		return errMultiInput
+	}
+	history, scannedAt, err := loadHistory(config, scans)
+	if err != nil {
+		return err
+	}
+	out := io.NewZipWriter(w, time.Now())
+	if _, err := writePreprocess(config, scans[0], history, scannedAt, out, warn); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// convertExcel은 Excel 파일을 기록합니다.
+func convertExcel(config *cli.Config, scans []*processor.TrivyResult, w stdio.Writer, warn func(string)) error {
+	history, scannedAt, err := loadHistory(config, scans)
+	if err != nil {
+		return err
+	}
+	excelData := prepareExcel(config, scans, history, scannedAt, warn)
+	return io.WriteExcelTo(w, excelData, io.ExcelOptions{
+		Details: config.ExcelDetails,
+		Lang:    config.Lang,
+	})
+}
+
+// readUploads는 요청 본문의 Trivy JSON을 읽습니다.
+// multipart/form-data이면 "file" 필드의 파일들을, 그 외에는 본문 전체를 JSON 1개로 읽습니다.
+func readUploads(w http.ResponseWriter, r *http.Request, maxUpload int64) ([]*processor.TrivyResult, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxUpload)
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		data, err := stdio.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		scan, err := io.DecodeResult(data)
+		if err != nil {
+			return nil, err
+		}
+		return []*processor.TrivyResult{scan}, nil
+	}
+
+	if err := r.ParseMultipartForm(maxUpload); err != nil {
+		return nil, err
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	files := r.MultipartForm.File["file"]
+	if len(files) == 0 {
+		return nil, errors.New(i18n.T("err.serve_no_file"))
+	}
+	scans := make([]*processor.TrivyResult, 0, len(files))
+	for _, header := range files {
+		file, err := header.Open()
+		if err != nil {
+			return nil, err
+		}
+		data, err := stdio.ReadAll(file)
+		file.Close()
+		if err != nil {
+			return nil, err
+		}
+		scan, err := io.DecodeResult(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", header.Filename, err)
+		}
+		scans = append(scans, scan)
+	}
+	return scans, nil
+}
+
+// writeJSONResponse는 JSON 응답을 기록합니다.
+func writeJSONResponse(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// writeError는 {"error": "..."} 형식의 오류 응답을 기록합니다.
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSONResponse(w, status, map[string]string{"error": err.Error()})
+}
+
+// statusRecorder는 접근 로그용으로 응답 상태 코드를 기록합니다.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// logRequests는 요청마다 메서드, 경로, 상태 코드, 처리 시간을 출력합니다.
+func (s *server) logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+		fmt.Println(i18n.T("cli.serve_request", r.Method, r.URL.Path, recorder.status, time.Since(start).Round(time.Millisecond)))
+	})
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	stdio "io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"trivy-parser/cli"
+	"trivy-parser/processor"
+)
+
+// testScanBody는 업로드용 최소 Trivy JSON입니다.
+const testScanBody = `{"SchemaVersion":2,"ArtifactName":"app","CreatedAt":"2026-01-01T00:00:00Z","Results":[]}`
+
+// newTestServerInternal은 변환 슬롯 capacity개의 서버를 만듭니다.
+func newTestServerInternal(capacity int, timeout time.Duration) *server {
+	return &server{
+		config: &cli.ServeConfig{MaxUpload: 1 << 20, Timeout: timeout, MaxConcurrent: capacity},
+		slots:  make(chan struct{}, capacity),
+	}
+}
+
+// postInternal은 handler에 요청을 보내고 응답을 반환합니다.
+func postInternal(handler http.Handler, target, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, target, strings.NewReader(body)))
+	return recorder
+}
+
+// waitSlotsInternal은 사용 중인 슬롯이 want개가 될 때까지 기다립니다.
+func waitSlotsInternal(t *testing.T, s *server, want int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for len(s.slots) != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("slots in use = %d, want %d", len(s.slots), want)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestConvertHandlerStatus(t *testing.T) {
+	ok := func(config *cli.Config, scans []*processor.TrivyResult, w stdio.Writer, warn func(string)) error {
+		_, err := w.Write([]byte("converted:" + scans[0].ArtifactName))
+		return err
+	}
+	failWith := func(err error) converter {
+		return func(*cli.Config, []*processor.TrivyResult, stdio.Writer, func(string)) error { return err }
+	}
+
+	tests := []struct {
+		name    string
+		target  string
+		body    string
+		convert converter
+		status  int
+	}{
+		{name: "ok", target: "/v1/preprocess?sort=severity", body: testScanBody, convert: ok, status: http.StatusOK},
+		{name: "rejected policy-dir", target: "/v1/preprocess?policy-dir=/etc", body: testScanBody, convert: ok, status: http.StatusBadRequest},
+		{name: "rejected blame", target: "/v1/preprocess?blame=/", body: testScanBody, convert: ok, status: http.StatusBadRequest},
+		{name: "rejected render file", target: "/v1/preprocess?render=/etc/passwd", body: testScanBody, convert: ok, status: http.StatusBadRequest},
+		{name: "invalid json", target: "/v1/preprocess", body: "{", convert: ok, status: http.StatusBadRequest},
+		{name: "too large", target: "/v1/preprocess", body: `{"ArtifactName":"` + strings.Repeat("x", 2<<20) + `"}`, convert: ok, status: http.StatusRequestEntityTooLarge},
+		{name: "no targets", target: "/v1/preprocess", body: testScanBody, convert: failWith(errNoTargets), status: http.StatusUnprocessableEntity},
+		{name: "multi input", target: "/v1/preprocess", body: testScanBody, convert: failWith(errMultiInput), status: http.StatusBadRequest},
+		{name: "conversion error", target: "/v1/preprocess", body: testScanBody, convert: failWith(errors.New("boom")), status: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServerInternal(1, time.Second)
+			response := postInternal(s.convertHandler("out.zip", contentTypeZip, tt.convert), tt.target, tt.body)
+			if response.Code != tt.status {
+				t.Fatalf("status = %d, want %d (%s)", response.Code, tt.status, response.Body.String())
+			}
+			if tt.status == http.StatusOK {
+				if got := response.Body.String(); got != "converted:app" {
+					t.Errorf("body = %q", got)
+				}
+				if got := response.Header().Get("Content-Disposition"); got != `attachment; filename=out.zip` {
+					t.Errorf("Content-Disposition = %q", got)
+				}
+			} else {
+				var body map[string]string
+				if err := json.Unmarshal(response.Body.Bytes(), &body); err != nil || body["error"] == "" {
+					t.Errorf("error body = %q", response.Body.String())
+				}
+			}
+			// 요청이 끝나면 슬롯이 반환됨
+			waitSlotsInternal(t, s, 0)
+		})
+	}
+}
+
+func TestConvertHandlerBusy(t *testing.T) {
+	s := newTestServerInternal(1, time.Second)
+	s.slots <- struct{}{} // 다른 변환이 진행 중
+
+	called := false
+	handler := s.convertHandler("out.zip", contentTypeZip, func(*cli.Config, []*processor.TrivyResult, stdio.Writer, func(string)) error {
+		called = true
+		return nil
+	})
+	response := postInternal(handler, "/v1/preprocess", testScanBody)
+	if response.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429", response.Code)
+	}
+	if response.Header().Get("Retry-After") == "" {
+		t.Error("missing Retry-After header")
+	}
+	if called {
+		t.Error("converter ran while all slots were busy")
+	}
+	if len(s.slots) != 1 {
+		t.Errorf("slots in use = %d, want 1 (busy request must not release another request's slot)", len(s.slots))
+	}
+}
+
+func TestConvertHandlerTimeout(t *testing.T) {
+	s := newTestServerInternal(1, 20*time.Millisecond)
+	release := make(chan struct{})
+	handler := s.convertHandler("out.zip", contentTypeZip, func(*cli.Config, []*processor.TrivyResult, stdio.Writer, func(string)) error {
+		<-release
+		return nil
+	})
+
+	response := postInternal(handler, "/v1/preprocess", testScanBody)
+	if response.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want 503", response.Code)
+	}
+
+	// 시간 초과 후에도 변환이 끝날 때까지 슬롯을 점유하여 다음 요청은 429
+	if len(s.slots) != 1 {
+		t.Fatalf("slots in use = %d, want 1 while conversion is still running", len(s.slots))
+	}
+	if response := postInternal(handler, "/v1/preprocess", testScanBody); response.Code != http.StatusTooManyRequests {
+		t.Errorf("status while busy = %d, want 429", response.Code)
+	}
+
+	close(release)
+	waitSlotsInternal(t, s, 0)
+}